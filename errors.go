@@ -0,0 +1,38 @@
+package validator
+
+import (
+	"strings"
+)
+
+// FieldError 单个字段的验证错误
+type FieldError struct {
+	Field   string      // 字段路径，如 User.Name
+	Rule    string      // 验证规则名称，如 range
+	Param   string      // 验证规则参数，如 6,20，没有参数时为空
+	Value   interface{} // 验证失败的值，无法获取时为 nil
+	Type    string      // 字段数据类型
+	Message string      // 错误信息
+}
+
+// Error 返回错误信息
+func (e *FieldError) Error() string {
+	return e.Message
+}
+
+// ValidationErrors 验证错误列表，Validate 验证不通过时返回该类型
+//
+//	if errs, ok := err.(validator.ValidationErrors); ok { ... }
+//	// 或者
+//	var errs validator.ValidationErrors
+//	errors.As(err, &errs)
+type ValidationErrors []*FieldError
+
+// Error 返回全部错误信息，以分号分隔
+func (e ValidationErrors) Error() string {
+	msgs := make([]string, 0, len(e))
+	for _, fe := range e {
+		msgs = append(msgs, fe.Message)
+	}
+
+	return strings.Join(msgs, "; ")
+}
@@ -0,0 +1,118 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+type errorsUser struct {
+	Name string `valid:"range:6,20"`
+	Age  int    `valid:"max:120"`
+}
+
+func TestValidationErrorsFields(t *testing.T) {
+	err := New().Struct(errorsUser{Name: "tom", Age: 200}).Validate()
+
+	var errs ValidationErrors
+	if !errors.As(fmt.Errorf("wrapped: %w", err), &errs) {
+		t.Fatalf("errors.As failed for %T", err)
+	}
+
+	want := []FieldError{
+		{Field: "errorsUser.Name", Rule: "range", Param: "6,20", Value: "tom", Type: "string", Message: "The errorsUser.Name must be between 6 and 20 characters."},
+		{Field: "errorsUser.Age", Rule: "max", Param: "120", Value: 200, Type: "int", Message: "The errorsUser.Age may not be greater than 120."},
+	}
+
+	if len(errs) != len(want) {
+		t.Fatalf("got %d errors, want %d: %v", len(errs), len(want), errs)
+	}
+
+	for i, fe := range errs {
+		if *fe != want[i] {
+			t.Errorf("error %d: got %+v, want %+v", i, *fe, want[i])
+		}
+	}
+}
+
+func TestValidationErrorsError(t *testing.T) {
+	errs := ValidationErrors{{Message: "a"}, {Message: "b"}}
+	if got := errs.Error(); got != "a; b" {
+		t.Errorf("Error() = %q", got)
+	}
+
+	err := New().Struct(errorsUser{Name: "tom", Age: 200}).Validate()
+	if got := err.Error(); got != "The errorsUser.Name must be between 6 and 20 characters.; The errorsUser.Age may not be greater than 120." {
+		t.Errorf("Error() = %q", got)
+	}
+}
+
+func TestValidateReturnsNil(t *testing.T) {
+	v := New()
+	if err := v.Struct(errorsUser{Name: "tommy boy", Age: 20}).Validate(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !v.Fails || len(v.Errors()) != 0 {
+		t.Errorf("Fails = %v, Errors = %v", v.Fails, v.Errors())
+	}
+}
+
+func TestLegacyErrorMsg(t *testing.T) {
+	v := New()
+	v.AddRule("Name", "string", "required", "").Validate()
+
+	if v.Fails {
+		t.Error("Fails should be false when validation fails")
+	}
+
+	if msg := v.ErrorMsg["Name.required"]; msg != "The Name field is required." {
+		t.Errorf("ErrorMsg = %v", v.ErrorMsg)
+	}
+
+	var fe *FieldError
+	if !errors.As(v.Errors()[0], &fe) || fe.Rule != "required" {
+		t.Errorf("FieldError = %+v", fe)
+	}
+}
+
+func TestValidateResetsErrors(t *testing.T) {
+	// 每次验证重新计算错误，上一次验证的错误不保留
+	pass := false
+	v := New()
+	v.TagMap["toggle"] = func(args ...reflect.Value) bool { return pass }
+	v.AddRule("Name", "string", "toggle", "tom")
+
+	if got := errorKeys(v.Validate()); !equalKeys(got, []string{"Name.toggle"}) {
+		t.Fatalf("first: got %v", got)
+	}
+
+	pass = true
+	if err := v.Validate(); err != nil || !v.Fails || len(v.ErrorMsg) != 0 {
+		t.Errorf("second: got %v, Fails = %v, ErrorMsg = %v", err, v.Fails, v.ErrorMsg)
+	}
+
+	pass = false
+	if got := errorKeys(v.Validate()); !equalKeys(got, []string{"Name.toggle"}) || len(v.Errors()) != 1 {
+		t.Errorf("third: got %v", got)
+	}
+}
+
+func TestMissingMapFields(t *testing.T) {
+	rules := map[string][]string{
+		"name":  {"string", "required"},
+		"age":   {"int", "max:120"},
+		"email": {"string", "sometimes|email"},
+	}
+
+	v := New().AddMapRule(rules, map[string]interface{}{})
+	want := []string{"age.null", "name.required"}
+
+	// 字段不存在的错误在验证时添加，多次验证结果相同
+	for i := 0; i < 2; i++ {
+		if got := errorKeys(v.Validate()); !equalKeys(got, want) {
+			t.Errorf("round %d: got %v, want %v", i, got, want)
+		}
+	}
+}
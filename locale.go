@@ -0,0 +1,161 @@
+package validator
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"strings"
+	"sync"
+)
+
+// 默认语言，查找错误信息时作为最后的回退语言
+const defaultLocale = "en"
+
+// 错误信息目录，locale => 规则名称 => 错误信息
+// 错误信息为字符串，或按字段类型（int、float、string、array、map、chan）区分的 map[string]string
+var catalogs = struct {
+	sync.RWMutex
+	m map[string]map[string]interface{}
+}{m: map[string]map[string]interface{}{
+	"en":    ruleErrorMsgMap,
+	"zh-cn": zhCNErrorMsgMap,
+}}
+
+// WithLocale 设置错误信息的语言及回退语言，如 WithLocale("zh-TW", "zh-CN")
+// 语言及回退语言依次查找，每个语言之后查找其上级语言（如 zh-CN 之后查找 zh），最后查找默认语言 en
+func WithLocale(locale string, fallbacks ...string) Option {
+	return func(v *Validator) {
+		v.locales = localeChain(append([]string{locale}, fallbacks...))
+	}
+}
+
+// RegisterCatalog 注册指定语言的错误信息，已存在的语言合并错误信息，可以在多个 goroutine 中调用
+// 错误信息的值为字符串，或按字段类型区分的 map[string]string、map[string]interface{}
+func RegisterCatalog(locale string, messages map[string]interface{}) error {
+	locale = normalizeLocale(locale)
+	if locale == "" {
+		return errors.New("data error: RegisterCatalog requires a locale.")
+	}
+
+	catalogs.Lock()
+	defer catalogs.Unlock()
+
+	catalog := make(map[string]interface{}, len(catalogs.m[locale])+len(messages))
+	for key, msg := range catalogs.m[locale] {
+		catalog[key] = msg
+	}
+
+	for key, msg := range messages {
+		switch msg := msg.(type) {
+		case string:
+			catalog[ruleName(key)] = msg
+		case map[string]string:
+			catalog[ruleName(key)] = msg
+		case map[string]interface{}:
+			typed := make(map[string]string, len(msg))
+			for typ, typeMsg := range msg {
+				str, ok := typeMsg.(string)
+				if !ok {
+					return errors.New("data error: Invalid message " + key + "." + typ + " in catalog " + locale + ".")
+				}
+
+				typed[typ] = str
+			}
+
+			catalog[ruleName(key)] = typed
+		default:
+			return errors.New("data error: Invalid message " + key + " in catalog " + locale + ".")
+		}
+	}
+
+	catalogs.m[locale] = catalog
+
+	return nil
+}
+
+// LoadCatalog 从 JSON 数据加载指定语言的错误信息，格式与 RegisterCatalog 相同，如 {"required": ":attribute は必須です"}
+func LoadCatalog(locale string, data []byte) error {
+	var messages map[string]interface{}
+	if err := json.Unmarshal(data, &messages); err != nil {
+		return errors.New("data error: Invalid catalog " + locale + ": " + err.Error())
+	}
+
+	return RegisterCatalog(locale, messages)
+}
+
+// LoadCatalogFile 从 JSON 文件加载指定语言的错误信息
+func LoadCatalogFile(locale, path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return errors.New("data error: " + err.Error())
+	}
+
+	return LoadCatalog(locale, data)
+}
+
+// LoadCatalogFS 从文件系统（如 embed.FS）中的 JSON 文件加载指定语言的错误信息
+func LoadCatalogFS(fsys fs.FS, locale, path string) error {
+	data, err := fs.ReadFile(fsys, path)
+	if err != nil {
+		return errors.New("data error: " + err.Error())
+	}
+
+	return LoadCatalog(locale, data)
+}
+
+// 规范化语言名称：转为小写，下划线转为中划线，如 zh_CN 为 zh-cn
+func normalizeLocale(locale string) string {
+	return strings.Replace(strings.ToLower(strings.TrimSpace(locale)), "_", "-", -1)
+}
+
+// 生成语言的查找顺序，每个语言之后为其上级语言，最后为默认语言
+func localeChain(locales []string) []string {
+	chain := make([]string, 0, len(locales)*2+1)
+	seen := make(map[string]bool)
+	add := func(locale string) {
+		if locale != "" && !seen[locale] {
+			seen[locale] = true
+			chain = append(chain, locale)
+		}
+	}
+
+	for _, locale := range locales {
+		locale = normalizeLocale(locale)
+		for locale != "" {
+			add(locale)
+
+			pos := strings.LastIndex(locale, "-")
+			if pos == -1 {
+				break
+			}
+
+			locale = locale[:pos]
+		}
+	}
+
+	add(defaultLocale)
+
+	return chain
+}
+
+// 按语言的查找顺序查找规则的错误信息，按字段类型区分的错误信息使用字段类型对应的错误信息
+func (v *Validator) catalogMessage(method, filedType string) (string, bool) {
+	catalogs.RLock()
+	defer catalogs.RUnlock()
+
+	for _, locale := range v.locales {
+		switch msg := catalogs.m[locale][method].(type) {
+		case string:
+			if msg != "" {
+				return msg, true
+			}
+		case map[string]string:
+			if typeMsg := msg[getTypeMapping(filedType)]; typeMsg != "" {
+				return typeMsg, true
+			}
+		}
+	}
+
+	return "", false
+}
@@ -0,0 +1,148 @@
+package validator
+
+import (
+	"testing"
+	"testing/fstest"
+)
+
+// 验证单个字段，返回第一个错误信息
+func firstMessage(v *Validator, rule string, value interface{}, locales ...string) string {
+	errs, ok := v.AddRule("Name", "string", rule, value).Validate(locales...).(ValidationErrors)
+	if !ok || len(errs) == 0 {
+		return ""
+	}
+
+	return errs[0].Message
+}
+
+func TestLocaleChain(t *testing.T) {
+	tests := []struct {
+		locales []string
+		want    []string
+	}{
+		{nil, []string{"en"}},
+		{[]string{"zh_CN"}, []string{"zh-cn", "zh", "en"}},
+		{[]string{"zh-Hant-TW", "zh-CN"}, []string{"zh-hant-tw", "zh-hant", "zh", "zh-cn", "en"}},
+		{[]string{" EN ", ""}, []string{"en"}},
+	}
+
+	for _, tt := range tests {
+		if got := localeChain(tt.locales); !equalKeys(got, tt.want) || len(got) != len(tt.want) {
+			t.Errorf("%v: got %v, want %v", tt.locales, got, tt.want)
+		}
+	}
+}
+
+func TestLocaleMessages(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    []Option
+		locales []string
+		rule    string
+		value   string
+		message string
+	}{
+		{"default", nil, nil, "required", "", "The Name field is required."},
+		{"WithLocale", []Option{WithLocale("zh-CN")}, nil, "required", "", "Name 不能为空"},
+		{"typed message", []Option{WithLocale("zh_cn")}, nil, "min:3", "a", "Name 至少为 3 个字符"},
+		{"Validate locale", nil, []string{"zh-CN"}, "email", "x", "Name 不是有效的邮箱地址"},
+		{"Validate locale overrides option", []Option{WithLocale("zh-CN")}, []string{"en"}, "required", "", "The Name field is required."},
+		{"fallback", []Option{WithLocale("fr-FR", "zh-CN")}, nil, "required", "", "Name 不能为空"},
+		{"fallback to parent locale", []Option{WithLocale("zh-CN-beijing")}, nil, "required", "", "Name 不能为空"},
+		{"fallback to en", []Option{WithLocale("fr")}, nil, "required", "", "The Name field is required."},
+	}
+
+	for _, tt := range tests {
+		if got := firstMessage(New(tt.opts...), tt.rule, tt.value, tt.locales...); got != tt.message {
+			t.Errorf("%s: got %q, want %q", tt.name, got, tt.message)
+		}
+	}
+
+	// Validate 指定的语言只对本次验证有效，再次验证时重新生成错误信息
+	v := New().AddRule("Name", "string", "required", "")
+	if got := v.Validate("zh-CN").Error(); got != "Name 不能为空" {
+		t.Errorf("zh-CN: got %q", got)
+	}
+
+	for _, locales := range [][]string{{"en"}, nil} {
+		if got := v.Validate(locales...).Error(); got != "The Name field is required." {
+			t.Errorf("Validate locale leaked into %v: got %q", locales, got)
+		}
+
+		if got := v.ErrorMsg["Name.required"]; got != "The Name field is required." {
+			t.Errorf("ErrorMsg locale leaked into %v: got %q", locales, got)
+		}
+	}
+}
+
+func TestRegisterCatalog(t *testing.T) {
+	tests := []struct {
+		name     string
+		locale   string
+		messages map[string]interface{}
+		wantErr  string
+	}{
+		{"string", "x-register", map[string]interface{}{"required": "必須"}, ""},
+		{"typed", "x-register", map[string]interface{}{"min": map[string]string{"string": "短すぎ :value"}}, ""},
+		{"typed interface", "x-register", map[string]interface{}{"max": map[string]interface{}{"string": "長すぎ :value"}}, ""},
+		{"empty locale", " ", map[string]interface{}{"required": "x"}, "data error: RegisterCatalog requires a locale."},
+		{"invalid message", "x-invalid", map[string]interface{}{"required": 1}, "data error: Invalid message required in catalog x-invalid."},
+		{"invalid typed message", "x-invalid", map[string]interface{}{"min": map[string]interface{}{"string": 1}}, "data error: Invalid message min.string in catalog x-invalid."},
+	}
+
+	for _, tt := range tests {
+		if got := errString(RegisterCatalog(tt.locale, tt.messages)); got != tt.wantErr {
+			t.Errorf("%s: got %q, want %q", tt.name, got, tt.wantErr)
+		}
+	}
+
+	// 多次注册合并错误信息，未注册的规则回退到 en
+	for _, tt := range []struct {
+		rule    string
+		value   string
+		message string
+	}{
+		{"required", "", "必須"},
+		{"min:3", "a", "短すぎ 3"},
+		{"max:1", "ab", "長すぎ 1"},
+		{"email", "a", "The Name must be a valid email address."},
+	} {
+		if got := firstMessage(New(WithLocale("X_Register")), tt.rule, tt.value); got != tt.message {
+			t.Errorf("%s: got %q, want %q", tt.rule, got, tt.message)
+		}
+	}
+}
+
+func TestLoadCatalog(t *testing.T) {
+	fsys := fstest.MapFS{
+		"i18n/ja.json":  {Data: []byte(`{"required": ":attribute は必須です", "min": {"string": ":attribute は :value 文字以上です"}}`)},
+		"i18n/bad.json": {Data: []byte(`{"required": `)},
+	}
+
+	tests := []struct {
+		name    string
+		load    func() error
+		wantErr string
+	}{
+		{"LoadCatalog", func() error { return LoadCatalog("x-load", []byte(`{"required": "必須"}`)) }, ""},
+		{"LoadCatalog invalid JSON", func() error { return LoadCatalog("x-load", []byte(`[]`)) }, "data error: Invalid catalog x-load: json: cannot unmarshal array into Go value of type map[string]interface {}"},
+		{"LoadCatalogFS", func() error { return LoadCatalogFS(fsys, "x-ja", "i18n/ja.json") }, ""},
+		{"LoadCatalogFS invalid JSON", func() error { return LoadCatalogFS(fsys, "x-bad", "i18n/bad.json") }, "data error: Invalid catalog x-bad: unexpected end of JSON input"},
+		{"LoadCatalogFS missing file", func() error { return LoadCatalogFS(fsys, "x-ja", "i18n/none.json") }, "data error: open i18n/none.json: file does not exist"},
+		{"LoadCatalogFile missing file", func() error { return LoadCatalogFile("x-ja", "testdata/none.json") }, "data error: open testdata/none.json: no such file or directory"},
+	}
+
+	for _, tt := range tests {
+		if got := errString(tt.load()); got != tt.wantErr {
+			t.Errorf("%s: got %q, want %q", tt.name, got, tt.wantErr)
+		}
+	}
+
+	if got := firstMessage(New(), "required", "", "x-load"); got != "必須" {
+		t.Errorf("LoadCatalog: got %q", got)
+	}
+
+	if got := firstMessage(New(WithLocale("x-ja")), "min:3", "a"); got != "Name は 3 文字以上です" {
+		t.Errorf("LoadCatalogFS: got %q", got)
+	}
+}
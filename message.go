@@ -0,0 +1,204 @@
+package validator
+
+import (
+	"strings"
+)
+
+// SetMessages 设置自定义错误信息，key 为规则名称或 字段.规则名称，如 required、User.Name.required
+// 结构体字段可以省略最外层的类型名称，如 Name.required；规则名称为 null 时设置字段不存在的错误信息
+// 错误信息中可以使用与默认错误信息相同的占位符，如 :attribute、:value
+func (v *Validator) SetMessages(messages map[string]string) *Validator {
+	for key, msg := range messages {
+		v.messages[messageKey(key)] = msg
+	}
+
+	return v
+}
+
+// 规范化错误信息的 key，规则名称转为标准名称
+func messageKey(key string) string {
+	field, rule := "", strings.TrimSpace(key)
+	if pos := strings.LastIndex(rule, "."); pos != -1 {
+		field, rule = rule[:pos+1], rule[pos+1:]
+	}
+
+	return field + ruleName(rule)
+}
+
+// 规则的标准名称，内置规则使用标准名称，其他规则统一转为小写
+func ruleName(name string) string {
+	if canonical, _, _, ok := lookupBuiltinRule(name); ok {
+		return canonical
+	}
+
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// 解析字段的自定义错误信息，格式为 规则名称:错误信息，多条以 | 分隔，如 "required:请输入用户名|range:用户名长度不正确"
+// 没有规则名称的错误信息（或规则名称为 default）作为字段所有规则的错误信息；
+// 错误信息中包含 | 或 : 时可以使用单引号包裹，如 "regex:'格式为 a|b'"
+func parseMessages(syntax tagSyntax, msgStrs ...string) (map[string]string, error) {
+	var messages map[string]string
+	for _, msgStr := range msgStrs {
+		if strings.TrimSpace(msgStr) == "" {
+			continue
+		}
+
+		tokens, err := tokenizeRule(msgStr, syntax)
+		if err != nil {
+			return nil, err
+		}
+
+		if messages == nil {
+			messages = make(map[string]string, len(tokens))
+		}
+
+		for _, token := range tokens {
+			if token.param == STR_NULL {
+				messages[STR_DEFAULT] = token.name
+				continue
+			}
+
+			messages[ruleName(token.name)] = token.param
+		}
+	}
+
+	return messages, nil
+}
+
+// 合并字段的自定义错误信息，后添加的优先
+func mergeMessages(messages, more map[string]string) map[string]string {
+	if len(more) == 0 {
+		return messages
+	}
+
+	merged := make(map[string]string, len(messages)+len(more))
+	for key, msg := range messages {
+		merged[key] = msg
+	}
+
+	for key, msg := range more {
+		merged[key] = msg
+	}
+
+	return merged
+}
+
+// 查找自定义错误信息，依次查找字段的 msg tag 或 AddRule 中设置的错误信息、SetMessages 中 字段.规则名称 及 规则名称 的错误信息
+func (v *Validator) customMessage(field *fieldData, method string) (string, bool) {
+	if msg, ok := field.messages[method]; ok {
+		return msg, true
+	}
+
+	if msg, ok := field.messages[STR_DEFAULT]; ok {
+		return msg, true
+	}
+
+	if msg, ok := v.messages[field.key+"."+method]; ok {
+		return msg, true
+	}
+
+	if field.rootKey != "" && strings.HasPrefix(field.key, field.rootKey+".") {
+		if msg, ok := v.messages[field.key[len(field.rootKey)+1:]+"."+method]; ok {
+			return msg, true
+		}
+	}
+
+	msg, ok := v.messages[method]
+
+	return msg, ok
+}
+
+// SetLabels 设置字段在错误信息中的显示名称，key 为字段标识，如 User.Name、mobile
+// 结构体字段可以省略最外层的类型名称，如 Name；优先于 label tag
+func (v *Validator) SetLabels(labels map[string]string) *Validator {
+	for key, label := range labels {
+		v.labels[strings.TrimSpace(key)] = label
+	}
+
+	return v
+}
+
+// 参数可以只有最大值的规则，只有最大值时使用 规则名称_max 的错误信息，如 bytes:30
+var maxOnlyRules = map[string]bool{
+	"bytes": true,
+	"width": true,
+}
+
+// 规则参数是否只有一个值
+func maxOnlyParam(valStr string) bool {
+	return valStr != STR_NULL && strings.TrimSpace(valStr) != "" && !strings.Contains(valStr, ",")
+}
+
+// 生成字段验证失败的错误信息
+func (v *Validator) fieldMessage(field *fieldData, method, valStr string) string {
+	if maxOnlyRules[method] && maxOnlyParam(valStr) {
+		if tpl, ok := v.catalogMessage(method+"_max", field.fieldType); ok {
+			return v.renderField(tpl, field, method, valStr)
+		}
+	}
+
+	return v.renderField(v.messageTemplate(method, field.fieldType), field, method, valStr)
+}
+
+// 替换错误信息中的占位符，:attribute 及参数中的字段名称使用字段的显示名称
+func (v *Validator) renderField(tpl string, field *fieldData, method, valStr string) string {
+	label := func(name string) string {
+		return v.fieldLabel(field, name)
+	}
+
+	return strings.NewReplacer(messageArgs(v.attributeLabel(field), method, valStr, label)...).Replace(tpl)
+}
+
+// 字段的显示名称，依次查找 SetLabels 设置的名称、label tag，都没有时使用字段标识
+func (v *Validator) attributeLabel(field *fieldData) string {
+	if label, ok := v.lookupLabel(field.key, field.rootKey); ok {
+		return label
+	}
+
+	if field.label != "" {
+		return field.label
+	}
+
+	return field.key
+}
+
+// 规则参数中字段名称的显示名称，依次按同级字段、最外层数据的字段及名称本身查找，不是字段名称时返回原名称
+func (v *Validator) fieldLabel(field *fieldData, name string) string {
+	keys := make([]string, 0, 3)
+	if pos := strings.LastIndex(field.key, "."); pos != -1 {
+		keys = append(keys, field.key[:pos+1]+name)
+	}
+
+	if field.rootKey != "" {
+		keys = append(keys, field.rootKey+"."+name)
+	}
+
+	keys = append(keys, name)
+
+	for _, key := range keys {
+		if label, ok := v.lookupLabel(key, field.rootKey); ok {
+			return label
+		}
+
+		if other, ok := v.fieldMap[key]; ok && other.label != "" {
+			return other.label
+		}
+	}
+
+	return name
+}
+
+// 查找 SetLabels 设置的显示名称，结构体字段可以省略最外层的类型名称
+func (v *Validator) lookupLabel(key, rootKey string) (string, bool) {
+	if label, ok := v.labels[key]; ok {
+		return label, true
+	}
+
+	if rootKey != "" && strings.HasPrefix(key, rootKey+".") {
+		label, ok := v.labels[key[len(rootKey)+1:]]
+		return label, ok
+	}
+
+	return "", false
+}
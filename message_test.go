@@ -0,0 +1,234 @@
+package validator
+
+import (
+	"testing"
+)
+
+// 错误列表中的错误信息
+func errorMessages(err error) []string {
+	errs, ok := err.(ValidationErrors)
+	if !ok {
+		return nil
+	}
+
+	msgs := make([]string, 0, len(errs))
+	for _, fe := range errs {
+		msgs = append(msgs, fe.Message)
+	}
+
+	return msgs
+}
+
+type messageUser struct {
+	Name  string `valid:"required|range:6,20" msg:"required:请输入用户名|range:用户名长度为 :min 到 :max 个字符"`
+	Email string `valid:"required|email" msg:"邮箱格式不正确"`
+	Code  string `valid:"regex:'^a|b$'" msg:"regex:'格式为 a|b'"`
+	Age   int    `valid:"min:18"`
+}
+
+func TestMessageSources(t *testing.T) {
+	tests := []struct {
+		name     string
+		messages map[string]string
+		want     []string
+	}{
+		{
+			"msg tag",
+			nil,
+			[]string{"请输入用户名", "用户名长度为 6 到 20 个字符", "邮箱格式不正确", "邮箱格式不正确", "格式为 a|b", "The messageUser.Age must be at least 18."},
+		},
+		{
+			"SetMessages by rule, field.rule and field without type name",
+			map[string]string{
+				"min":                  "太小了",
+				"messageUser.Age.min":  ":attribute 必须大于 :value",
+				"Email.required":       "not used, msg tag wins",
+				"Code.regex":           "not used, msg tag wins",
+				"messageUser.Name.min": "not used, rule does not apply",
+			},
+			[]string{"请输入用户名", "用户名长度为 6 到 20 个字符", "邮箱格式不正确", "邮箱格式不正确", "格式为 a|b", "messageUser.Age 必须大于 18"},
+		},
+		{
+			"SetMessages rule fallback",
+			map[string]string{"min": "太小了"},
+			[]string{"请输入用户名", "用户名长度为 6 到 20 个字符", "邮箱格式不正确", "邮箱格式不正确", "格式为 a|b", "太小了"},
+		},
+	}
+
+	for _, tt := range tests {
+		v := New()
+		if tt.messages != nil {
+			v.SetMessages(tt.messages)
+		}
+
+		got := errorMessages(v.Struct(messageUser{Code: "c"}).Validate())
+		if !equalKeys(got, tt.want) {
+			t.Errorf("%s: got %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestSetMessagesRuleNames(t *testing.T) {
+	// SetMessages 中的规则名称按规则的标准名称匹配
+	v := New().SetMessages(map[string]string{
+		"isEmail":         "邮箱不正确",
+		"Mobile.cnMobile": "手机号不正确",
+	})
+
+	err := v.AddRule("Email", "string", "email", "x").AddRule("Mobile", "string", "cn_mobile", "1").Validate()
+	want := []string{"邮箱不正确", "手机号不正确"}
+	if got := errorMessages(err); !equalKeys(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestPerCallMessages(t *testing.T) {
+	tests := []struct {
+		name  string
+		build func(v *Validator) *Validator
+		want  []string
+	}{
+		{
+			"AddRule",
+			func(v *Validator) *Validator {
+				return v.AddRule("name", "string", "required|min:3", "", "required:请输入名称", "min:至少 :value 个字符")
+			},
+			[]string{"请输入名称", "至少 3 个字符"},
+		},
+		{
+			"AddRule merged on the same field",
+			func(v *Validator) *Validator {
+				return v.AddRule("name", "string", "required", "", "名称有误").
+					AddRule("name", "string", "min:3", "", "min:太短")
+			},
+			[]string{"名称有误", "太短"},
+		},
+		{
+			"AddMapRule",
+			func(v *Validator) *Validator {
+				return v.AddMapRule(map[string][]string{
+					"name": {"string", "required", "required:请输入名称"},
+					"age":  {"int", "min:18"},
+				}, map[string]interface{}{"age": 1})
+			},
+			[]string{"The age must be at least 18.", "请输入名称"},
+		},
+		{
+			"missing field",
+			func(v *Validator) *Validator {
+				return v.AddMapRule(map[string][]string{"age": {"int", "min:18", "null:请填写年龄"}}, map[string]interface{}{})
+			},
+			[]string{"请填写年龄"},
+		},
+	}
+
+	for _, tt := range tests {
+		if got := errorMessages(tt.build(New()).Validate()); !equalKeys(got, tt.want) {
+			t.Errorf("%s: got %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestMessageParseErrors(t *testing.T) {
+	err := New().AddRule("name", "string", "required", "", "required:'未结束").Validate()
+	if got := errString(err); got != "rule error: Unterminated quoted parameter of required." {
+		t.Errorf("got %q", got)
+	}
+
+	_, err = Compile(struct {
+		X string `valid:"required" msg:"required:'x"`
+	}{})
+	if err == nil {
+		t.Error("Compile should report invalid msg tags")
+	}
+}
+
+type labelAccount struct {
+	Name     string `valid:"range:6,20" label:"用户名"`
+	Password string `valid:"required" label:"密码"`
+	Repeat   string `valid:"same:Password"`
+	Email    string
+	Mobile   string `valid:"nullable" label:"手机号"`
+	Contact  string `valid:"required_without:Email,Mobile"`
+}
+
+func TestLabels(t *testing.T) {
+	tests := []struct {
+		name   string
+		labels map[string]string
+		want   []string
+	}{
+		{
+			"label tag",
+			nil,
+			[]string{
+				"The 用户名 must be between 6 and 20 characters.",
+				"The 密码 field is required.",
+				"The labelAccount.Repeat and 密码 must match.",
+				"The labelAccount.Contact field is required when Email, 手机号 is not present.",
+			},
+		},
+		{
+			"SetLabels overrides label tag",
+			map[string]string{"Name": "账号", "labelAccount.Repeat": "确认密码", "Password": "登录密码", "Email": "邮箱"},
+			[]string{
+				"The 账号 must be between 6 and 20 characters.",
+				"The 登录密码 field is required.",
+				"The 确认密码 and 登录密码 must match.",
+				"The labelAccount.Contact field is required when 邮箱, 手机号 is not present.",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		v := New()
+		if tt.labels != nil {
+			v.SetLabels(tt.labels)
+		}
+
+		got := errorMessages(v.Struct(labelAccount{Name: "a", Repeat: "x"}).Validate())
+		if !equalKeys(got, tt.want) {
+			t.Errorf("%s: got %q, want %q", tt.name, got, tt.want)
+		}
+	}
+
+	// map 数据使用 SetLabels 设置的名称
+	err := New().SetLabels(map[string]string{"mobile": "手机号"}).
+		AddMapRule(map[string][]string{"mobile": {"string", "required"}}, map[string]interface{}{}).Validate()
+	if got := errorMessages(err); !equalKeys(got, []string{"The 手机号 field is required."}) {
+		t.Errorf("map data: got %q", got)
+	}
+}
+
+func TestPlaceholders(t *testing.T) {
+	tests := []struct {
+		rule      string
+		fieldType string
+		value     interface{}
+		message   string
+	}{
+		{"range:6,20", "string", "a", "The Field must be between 6 and 20 characters."},
+		{"range:1, 5", "int", 9, "The Field must be between 1 and 5."},
+		{"max:3", "string", "abcd", "The Field may not be greater than 3 characters."},
+		{"size:2", "slice", []int{1}, "The Field must contain 2 items."},
+		{"digits:4", "string", "1", "The Field must be 4 digits."},
+		{"digits_between:2,4", "string", "1", "The Field must be between 2 and 4 digits."},
+		{"date_format:%Y", "string", "x", "The Field does not match the format %Y."},
+		{"after:2020-01-01", "string", "2019-01-01", "The Field must be a date after 2020-01-01."},
+		{"required_without:Email,Mobile", "string", "", "The Field field is required when Email, Mobile is not present."},
+		{"required_unless:Type,a,b", "string", "", "The Field field is required unless Type is in a, b."},
+	}
+
+	for _, tt := range tests {
+		err := New().AddRule("Field", tt.fieldType, tt.rule, tt.value).Validate()
+		if got := errorMessages(err); len(got) == 0 || got[0] != tt.message {
+			t.Errorf("%s: got %q, want %q", tt.rule, got, tt.message)
+		}
+	}
+
+	// 自定义错误信息同样替换占位符
+	err := New().AddRule("Field", "string", "range:6,20", "a", "range::attribute 应为 :min-:max 位，当前规则 :value").SetLabels(map[string]string{"Field": "昵称"}).Validate()
+	if got := errorMessages(err); !equalKeys(got, []string{"昵称 应为 6-20 位，当前规则 6,20"}) {
+		t.Errorf("custom message: got %q", got)
+	}
+}
@@ -0,0 +1,62 @@
+package validator
+
+import (
+	"reflect"
+	"strings"
+)
+
+// FieldNamer 生成结构体字段在错误 key、错误信息及嵌套路径中使用的名称，返回空字符串时使用字段名
+type FieldNamer func(field reflect.StructField) string
+
+// TagNamer 使用指定 tag 中的名称作为字段名称，如 TagNamer("json")、TagNamer("form")
+// 名称取 tag 中第一个逗号之前的部分，tag 不存在、名称为空或为 - 时使用字段名
+func TagNamer(tag string) FieldNamer {
+	return func(field reflect.StructField) string {
+		name := field.Tag.Get(tag)
+		if pos := strings.Index(name, ","); pos != -1 {
+			name = name[:pos]
+		}
+
+		name = strings.TrimSpace(name)
+		if name == "-" {
+			return ""
+		}
+
+		return name
+	}
+}
+
+// WithFieldNamer 设置结构体字段的命名方式，默认使用字段名，如 WithFieldNamer(TagNamer("json"))
+// 验证规则中引用其他字段（如 same:Password）时，字段名及命名后的名称均可使用
+func WithFieldNamer(namer FieldNamer) Option {
+	return func(v *Validator) {
+		v.namer = namer
+	}
+}
+
+// WithRootName 设置结构体字段的路径是否以结构体类型名称开头，默认为 true，如 User.Email；为 false 时为 Email
+func WithRootName(include bool) Option {
+	return func(v *Validator) {
+		v.rootName = include
+	}
+}
+
+// 结构体字段的名称
+func (v *Validator) fieldName(field reflect.StructField) string {
+	if v.namer != nil {
+		if name := v.namer(field); name != "" {
+			return name
+		}
+	}
+
+	return field.Name
+}
+
+// 拼接字段路径，prefix 为空时直接使用字段名称
+func joinKey(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+
+	return prefix + "." + name
+}
@@ -0,0 +1,162 @@
+package validator
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type namingProfile struct {
+	Email string `json:"email" form:"mail" valid:"email"`
+}
+
+type namingBase struct {
+	Nick string `json:"nick"`
+}
+
+type namingUser struct {
+	*namingBase
+	Name    string          `json:"name,omitempty" valid:"required"`
+	Pw      string          `json:"password"`
+	Pw2     string          `json:"password2" valid:"same:password"`
+	Nick2   string          `json:"nick2" valid:"same:nick"`
+	Mail    string          `json:"mail_copy" valid:"same:profile.email"`
+	Skip    string          `json:"-" valid:"required"`
+	Profile namingProfile   `json:"profile"`
+	Items   []namingProfile `json:"items" valid:"dive"`
+}
+
+func TestFieldNamer(t *testing.T) {
+	user := namingUser{
+		namingBase: &namingBase{Nick: "a"},
+		Pw:         "secret",
+		Pw2:        "other",
+		Nick2:      "b",
+		Mail:       "a@b.cn",
+		Profile:    namingProfile{Email: "x"},
+		Items:      []namingProfile{{Email: "a@b.cn"}, {Email: "y"}},
+	}
+
+	tests := []struct {
+		name string
+		opts []Option
+		want []string
+	}{
+		{
+			"go field names",
+			nil,
+			[]string{
+				"namingUser.Name.required", "namingUser.Pw2.same", "namingUser.Nick2.same", "namingUser.Mail.same",
+				"namingUser.Skip.required", "namingUser.Profile.Email.email", "namingUser.Items[1].Email.email",
+			},
+		},
+		{
+			"json tag",
+			[]Option{WithFieldNamer(TagNamer("json"))},
+			[]string{
+				"namingUser.name.required", "namingUser.password2.same", "namingUser.nick2.same", "namingUser.mail_copy.same",
+				"namingUser.Skip.required", "namingUser.profile.email.email", "namingUser.items[1].email.email",
+			},
+		},
+		{
+			"json tag without root name",
+			[]Option{WithFieldNamer(TagNamer("json")), WithRootName(false)},
+			[]string{
+				"name.required", "password2.same", "nick2.same", "mail_copy.same",
+				"Skip.required", "profile.email.email", "items[1].email.email",
+			},
+		},
+		{
+			"custom namer",
+			[]Option{WithFieldNamer(func(field reflect.StructField) string { return strings.ToUpper(field.Name) }), WithRootName(false)},
+			[]string{
+				"NAME.required", "PW2.same", "NICK2.same", "MAIL.same",
+				"SKIP.required", "PROFILE.EMAIL.email", "ITEMS[1].EMAIL.email",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		got := errorKeys(New(tt.opts...).Struct(&user).Validate())
+		if !equalKeys(got, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestFieldNamerSibling(t *testing.T) {
+	tests := []struct {
+		name string
+		user namingUser
+		want []string
+	}{
+		{
+			// 被引用的字段没有验证规则，按命名后的名称从结构体中查找
+			"untagged target matches",
+			namingUser{namingBase: &namingBase{Nick: "a"}, Name: "a", Skip: "a", Pw: "secret", Pw2: "secret", Nick2: "a", Mail: "a@b.cn", Profile: namingProfile{Email: "a@b.cn"}},
+			nil,
+		},
+		{
+			"untagged target differs",
+			namingUser{namingBase: &namingBase{Nick: "a"}, Name: "a", Skip: "a", Pw: "secret", Pw2: "x", Nick2: "b", Mail: "c@d.cn", Profile: namingProfile{Email: "a@b.cn"}},
+			[]string{"namingUser.password2.same", "namingUser.nick2.same", "namingUser.mail_copy.same"},
+		},
+		{
+			// 嵌入的 nil 指针中的字段不存在
+			"nil embedded struct",
+			namingUser{Name: "a", Skip: "a", Pw: "secret", Pw2: "secret", Mail: "a@b.cn", Profile: namingProfile{Email: "a@b.cn"}},
+			[]string{"namingUser.nick2.same"},
+		},
+	}
+
+	for _, tt := range tests {
+		got := errorKeys(New(WithFieldNamer(TagNamer("json"))).Struct(tt.user).Validate())
+		if !equalKeys(got, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestSiblingNames(t *testing.T) {
+	user := namingUser{Pw: "secret", Profile: namingProfile{Email: "a@b.cn"}}
+	v := New(WithFieldNamer(TagNamer("form")))
+
+	tests := []struct {
+		name  string
+		want  interface{}
+		found bool
+	}{
+		{"password", nil, false},
+		{"Pw", "secret", true},
+		{"Profile.mail", "a@b.cn", true},
+		{"Profile.Email", "a@b.cn", true},
+		{"namingUser.Pw", "secret", true},
+		{"Nick", nil, false},
+		{"Missing", nil, false},
+	}
+
+	for _, tt := range tests {
+		fc := &FieldContext{Parent: reflect.ValueOf(user), root: reflect.ValueOf(user), rootKey: "namingUser", v: v}
+		val, ok := fc.Sibling(tt.name)
+		if ok != tt.found || (ok && val.Interface() != tt.want) {
+			t.Errorf("%s: got %v, %v, want %v, %v", tt.name, val, ok, tt.want, tt.found)
+		}
+	}
+}
+
+func TestTagNamer(t *testing.T) {
+	typ := reflect.TypeOf(struct {
+		A string `json:"a,omitempty"`
+		B string `json:"-"`
+		C string `json:",omitempty"`
+		D string
+		E string `json:" e "`
+	}{})
+
+	want := []string{"a", "", "", "", "e"}
+	for i, name := range want {
+		if got := TagNamer("json")(typ.Field(i)); got != name {
+			t.Errorf("%s: got %q, want %q", typ.Field(i).Name, got, name)
+		}
+	}
+}
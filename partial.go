@@ -0,0 +1,156 @@
+package validator
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+)
+
+// Only 只验证指定的字段，如 Struct(u).Only("Name", "Email").Validate()
+// 字段名称为不含结构体类型名称的路径，如 Address.City；指定结构体或数组字段时，其子字段和元素一并验证
+// 使用 WithFieldNamer 时，命名后的路径和字段名组成的路径均可使用；名称与所有字段均不匹配时 Validate 返回错误
+// 关联规则（如 same:Password）仍可以引用未选择的字段
+func (v *Validator) Only(fields ...string) *Validator {
+	v.only = fields
+
+	return v
+}
+
+// Except 不验证指定的字段，字段名称的格式与 Only 相同，同时设置时先按 Only 选择再排除
+func (v *Validator) Except(fields ...string) *Validator {
+	v.except = fields
+
+	return v
+}
+
+// OnlyPresent 只验证数据中存在的字段，用于 PATCH 等部分更新的请求
+// AddMapRule 中 dataVal 不包含的 key 不验证，包括 required 规则；值为 nil 的 key 视为存在
+func (v *Validator) OnlyPresent() *Validator {
+	v.onlyPresent = true
+
+	return v
+}
+
+// 检查 Only、Except 中的字段名称，名称与所有已添加的字段均不匹配时返回错误
+func (v *Validator) checkSelection() error {
+	for _, sel := range []struct {
+		method string
+		names  []string
+	}{{"Only", v.only}, {"Except", v.except}} {
+		for _, name := range sel.names {
+			if !v.matchAny(name) {
+				return errors.New("rule error: Undefined field " + strings.TrimSpace(name) + " in " + sel.method + ".")
+			}
+		}
+	}
+
+	return nil
+}
+
+// 名称是否与其中一个已添加的字段匹配
+func (v *Validator) matchAny(name string) bool {
+	for _, field := range v.fields {
+		if v.matchField(field, name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// 字段是否需要验证
+func (v *Validator) selected(field *fieldData) bool {
+	if v.onlyPresent && !field.value.IsValid() {
+		return false
+	}
+
+	if len(v.only) > 0 && !v.matchFields(field, v.only) {
+		return false
+	}
+
+	return !v.matchFields(field, v.except)
+}
+
+// 字段路径是否与其中一个名称匹配
+func (v *Validator) matchFields(field *fieldData, names []string) bool {
+	for _, name := range names {
+		if v.matchField(field, name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// 字段路径是否与名称匹配，名称可以包含或不包含结构体类型名称
+// 名称可以使用 WithFieldNamer 命名后的路径，也可以使用字段名组成的路径，如 address.zip_code、Address.ZipCode
+func (v *Validator) matchField(field *fieldData, name string) bool {
+	name = strings.TrimSpace(name)
+
+	relKey := field.key
+	if field.rootKey != "" {
+		relKey = strings.TrimPrefix(relKey, field.rootKey+".")
+	}
+
+	if matchPath(relKey, name) || matchPath(field.key, name) {
+		return true
+	}
+
+	if v.namer == nil || field.root.Kind() != reflect.Struct {
+		return false
+	}
+
+	rootT := field.root.Type()
+
+	return matchPath(relKey, v.namedPath(rootT, strings.TrimPrefix(name, rootT.Name()+".")))
+}
+
+// 把字段名组成的路径转换为 WithFieldNamer 命名后的路径，无法对应到结构体字段的部分保持不变
+func (v *Validator) namedPath(t reflect.Type, path string) string {
+	parts := strings.Split(path, ".")
+	for i, part := range parts {
+		for t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+
+		if t.Kind() != reflect.Struct {
+			break
+		}
+
+		fieldName, index := part, ""
+		if pos := strings.IndexByte(part, '['); pos != -1 {
+			fieldName, index = part[:pos], part[pos:]
+		}
+
+		field, ok := t.FieldByName(fieldName)
+		if !ok {
+			break
+		}
+
+		parts[i] = v.fieldName(field) + index
+
+		// 每个下标对应一层数组、切片或 map 的元素
+		t = field.Type
+		for n := strings.Count(index, "["); n > 0; n-- {
+			for t.Kind() == reflect.Ptr {
+				t = t.Elem()
+			}
+
+			switch t.Kind() {
+			case reflect.Array, reflect.Slice, reflect.Map:
+				t = t.Elem()
+			}
+		}
+	}
+
+	return strings.Join(parts, ".")
+}
+
+// 路径与名称相同，或为名称对应字段的子字段、元素
+func matchPath(key, name string) bool {
+	if name == "" || !strings.HasPrefix(key, name) {
+		return false
+	}
+
+	return len(key) == len(name) || key[len(name)] == '.' || key[len(name)] == '['
+}
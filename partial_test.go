@@ -0,0 +1,178 @@
+package validator
+
+import (
+	"testing"
+)
+
+type partialAddress struct {
+	City string `valid:"required"`
+	Zip  string `valid:"digits:6"`
+}
+
+type partialUser struct {
+	Name     string           `valid:"required"`
+	Email    string           `valid:"required|email"`
+	Password string           `valid:"required"`
+	Repeat   string           `valid:"same:Password"`
+	Address  partialAddress   `valid:"required"`
+	Others   []partialAddress `valid:"dive"`
+}
+
+func TestOnlyExcept(t *testing.T) {
+	user := partialUser{Repeat: "x", Others: []partialAddress{{City: "a", Zip: "1"}}}
+
+	tests := []struct {
+		name   string
+		only   []string
+		except []string
+		want   []string
+	}{
+		{"all", nil, nil, []string{"Name.required", "Email.required", "Email.email", "Password.required", "Repeat.same", "Address.required", "Address.City.required", "Address.Zip.digits", "Others[0].Zip.digits"}},
+		{"only", []string{"Name", " Email "}, nil, []string{"Name.required", "Email.required", "Email.email"}},
+		{"only with type name", []string{"partialUser.Name"}, nil, []string{"Name.required"}},
+		{"only struct field", []string{"Address"}, nil, []string{"Address.required", "Address.City.required", "Address.Zip.digits"}},
+		{"only nested field", []string{"Address.City"}, nil, []string{"Address.City.required"}},
+		{"only slice", []string{"Others"}, nil, []string{"Others[0].Zip.digits"}},
+		{"only element", []string{"Others[0].Zip"}, nil, []string{"Others[0].Zip.digits"}},
+		{"only references unselected field", []string{"Repeat"}, nil, []string{"Repeat.same"}},
+		{"except", nil, []string{"Email", "Address", "Others"}, []string{"Name.required", "Password.required", "Repeat.same"}},
+		{"only then except", []string{"Address", "Name"}, []string{"Address.Zip"}, []string{"Name.required", "Address.required", "Address.City.required"}},
+	}
+
+	for _, tt := range tests {
+		var want []string
+		for _, key := range tt.want {
+			want = append(want, "partialUser."+key)
+		}
+
+		got := errorKeys(New().Struct(user).Only(tt.only...).Except(tt.except...).Validate())
+		if !equalKeys(got, want) {
+			t.Errorf("%s: got %v, want %v", tt.name, got, want)
+		}
+	}
+}
+
+type partialNamed struct {
+	Name    string                `json:"name" valid:"required"`
+	Address partialNamedAddress   `json:"address"`
+	Others  []partialNamedAddress `json:"others" valid:"dive"`
+}
+
+type partialNamedAddress struct {
+	ZipCode string `json:"zip_code" valid:"digits:6"`
+}
+
+func TestOnlyExceptFieldNamer(t *testing.T) {
+	user := partialNamed{Address: partialNamedAddress{ZipCode: "1"}, Others: []partialNamedAddress{{ZipCode: "1"}}}
+
+	tests := []struct {
+		name   string
+		only   []string
+		except []string
+		want   []string
+	}{
+		{"named path", []string{"address.zip_code"}, nil, []string{"address.zip_code.digits"}},
+		{"go path", []string{"Address.ZipCode"}, nil, []string{"address.zip_code.digits"}},
+		{"go path with type name", []string{"partialNamed.Address"}, nil, []string{"address.zip_code.digits"}},
+		{"go path of element", []string{"Others[0].ZipCode"}, nil, []string{"others[0].zip_code.digits"}},
+		{"except go path", nil, []string{"Name", "Others"}, []string{"address.zip_code.digits"}},
+	}
+
+	for _, tt := range tests {
+		var want []string
+		for _, key := range tt.want {
+			want = append(want, "partialNamed."+key)
+		}
+
+		got := errorKeys(New(WithFieldNamer(TagNamer("json"))).Struct(user).Only(tt.only...).Except(tt.except...).Validate())
+		if !equalKeys(got, want) {
+			t.Errorf("%s: got %v, want %v", tt.name, got, want)
+		}
+	}
+}
+
+func TestOnlyExceptUndefinedField(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    []Option
+		only    []string
+		except  []string
+		wantErr string
+	}{
+		{"prefix is not a parent", nil, []string{"Name", "Emai"}, nil, "rule error: Undefined field Emai in Only."},
+		{"except", nil, nil, []string{"Other"}, "rule error: Undefined field Other in Except."},
+		{"renamed path without namer", nil, []string{"address.zip_code"}, nil, "rule error: Undefined field address.zip_code in Only."},
+		{"go path with namer", []Option{WithFieldNamer(TagNamer("json"))}, []string{"Address.ZipCode"}, nil, ""},
+	}
+
+	for _, tt := range tests {
+		err := New(tt.opts...).Struct(partialNamed{Name: "a"}).Only(tt.only...).Except(tt.except...).Validate()
+		if _, ok := err.(ValidationErrors); ok {
+			err = nil
+		}
+
+		if got := errString(err); got != tt.wantErr {
+			t.Errorf("%s: got %q, want %q", tt.name, got, tt.wantErr)
+		}
+	}
+}
+
+func TestOnlyPresent(t *testing.T) {
+	rules := map[string][]string{
+		"name":  {"string", "required|min:2"},
+		"email": {"string", "required|email"},
+		"age":   {"int", "min:18"},
+	}
+
+	tests := []struct {
+		name string
+		data map[string]interface{}
+		only []string
+		want []string
+	}{
+		{"empty", map[string]interface{}{}, nil, nil},
+		{"present fields", map[string]interface{}{"name": "a", "age": 10}, nil, []string{"age.min", "name.min"}},
+		{"nil is present", map[string]interface{}{"email": nil}, nil, []string{"email.required"}},
+		{"with only", map[string]interface{}{"name": "a", "age": 10}, []string{"age"}, []string{"age.min"}},
+	}
+
+	for _, tt := range tests {
+		got := errorKeys(New().OnlyPresent().Only(tt.only...).AddMapRule(rules, tt.data).Validate())
+		if !equalKeys(got, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	// 未设置 OnlyPresent 时缺少的 key 仍按 required 验证
+	if got := errorKeys(New().AddMapRule(rules, map[string]interface{}{}).Validate()); !equalKeys(got, []string{"age.null", "email.required", "name.required"}) {
+		t.Errorf("without OnlyPresent: got %v", got)
+	}
+}
+
+func TestPartialClearError(t *testing.T) {
+	rules := map[string][]string{
+		"name":  {"string", "required"},
+		"email": {"string", "required|email"},
+	}
+
+	tests := []struct {
+		name  string
+		setup func(v *Validator) *Validator
+	}{
+		{"Only", func(v *Validator) *Validator { return v.Only("name") }},
+		{"Except", func(v *Validator) *Validator { return v.Except("email") }},
+		{"OnlyPresent", func(v *Validator) *Validator { return v.OnlyPresent() }},
+	}
+
+	for _, tt := range tests {
+		v := New()
+		tt.setup(v).AddMapRule(rules, map[string]interface{}{"name": "a"}).Validate()
+
+		// ClearError 之后不再使用之前的字段选择
+		v.ClearError()
+		got := errorKeys(v.AddMapRule(rules, map[string]interface{}{"name": "a"}).Validate())
+		if !equalKeys(got, []string{"email.required"}) {
+			t.Errorf("%s: got %v", tt.name, got)
+		}
+	}
+}
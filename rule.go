@@ -53,7 +53,7 @@ func (r *Rules) Numeric(ruleVal, fieldType string, fieldVal reflect.Value) bool
 	return rxNumeric.MatchString(str)
 }
 
-// Range 验证大小必须在给定的 min 和 max 之间。字符串、数字、数组和文件的计算方式都使用 len 方法
+// Range 验证大小必须在给定的 min 和 max 之间。字符串比较字符个数，数字比较数值，数组、切片、map、chan 比较长度
 // rule exp "range:min,max"
 func (r *Rules) Range(ruleVal, fieldType string, fieldVal reflect.Value) bool {
 	//包含分隔符
@@ -76,35 +76,19 @@ func (r *Rules) Range(ruleVal, fieldType string, fieldVal reflect.Value) bool {
 			return false
 		}
 
-		str := fieldVal.String()
-		tempStr := string([]rune(str))
-		length := len(tempStr)
+		length := utf8.RuneCountInString(fieldVal.String())
 
 		if length < min || length > max {
 			return false
 		}
-	} else if typeStr == "float" {
-		min, minErr := strconv.ParseFloat(strArr[0], 64)
-		max, maxErr := strconv.ParseFloat(strArr[1], 64)
-		if minErr != nil || maxErr != nil {
-			return false
-		}
-
-		dataVal := float64(fieldVal.Float())
-
-		if dataVal < min || dataVal > max {
-			return false
-		}
-	} else if typeStr == "int" {
-		min, minErr := strconv.ParseInt(strArr[0], 10, 64)
-		max, maxErr := strconv.ParseInt(strArr[1], 10, 64)
-		if minErr != nil || maxErr != nil {
+	} else if typeStr == "int" || typeStr == "float" { // 有符号、无符号整数及浮点数
+		minC, minOk := compareNumberParam(fieldVal, strArr[0])
+		maxC, maxOk := compareNumberParam(fieldVal, strArr[1])
+		if !minOk || !maxOk {
 			return false
 		}
 
-		dataVal := fieldVal.Int()
-
-		if dataVal < min || dataVal > max {
+		if minC < 0 || maxC > 0 {
 			return false
 		}
 	} else if typeStr == "array" || typeStr == "map" || typeStr == "chan" { // Array, Slice, Map, Chan
@@ -133,12 +117,13 @@ func (r *Rules) In(ruleVal, fieldType string, fieldVal reflect.Value) bool {
 	compareStr := ""
 	if typeStr == "string" {
 		compareStr = fieldVal.String()
-	} else if typeStr == "int" {
-		iVal := fieldVal.Int()
-		compareStr = strconv.FormatInt(iVal, 10)
-	} else if typeStr == "float" {
-		fVal := fieldVal.Float()
-		compareStr = strconv.FormatFloat(fVal, 'f', -1, 64)
+	} else if typeStr == "int" || typeStr == "float" {
+		numStr, ok := numberString(fieldVal)
+		if !ok {
+			return false
+		}
+
+		compareStr = numStr
 	} else {
 		return false
 	}
@@ -167,32 +152,14 @@ func (r *Rules) Min(ruleVal, fieldType string, fieldVal reflect.Value) bool {
 			return false
 		}
 
-		str := fieldVal.String()
-		tempStr := string([]rune(str))
-
-		if len(tempStr) < val {
-			return false
-		}
-	} else if typeStr == "float" { // 浮点
-		val, err := strconv.ParseFloat(ruleVal, 64)
-		if err != nil {
-			return false
-		}
-
-		fVal := fieldVal.Float()
+		length := utf8.RuneCountInString(fieldVal.String())
 
-		if fVal < float64(val) {
-			return false
-		}
-	} else if typeStr == "int" { //整型
-		val, err := strconv.ParseInt(ruleVal, 10, 64)
-		if err != nil {
+		if length < val {
 			return false
 		}
-
-		iVal := fieldVal.Int()
-
-		if iVal < val {
+	} else if typeStr == "int" || typeStr == "float" { // 有符号、无符号整数及浮点数
+		c, ok := compareNumberParam(fieldVal, ruleVal)
+		if !ok || c < 0 {
 			return false
 		}
 	} else if typeStr == "array" || typeStr == "map" || typeStr == "chan" { // Array, Slice, Map, Chan
@@ -222,52 +189,288 @@ func (r *Rules) Max(ruleVal, fieldType string, fieldVal reflect.Value) bool {
 			return false
 		}
 
-		str := fieldVal.String()
-		tempStr := string([]rune(str))
+		length := utf8.RuneCountInString(fieldVal.String())
 
-		if len(tempStr) > val {
+		if length > val {
+			return false
+		}
+	} else if typeStr == "int" || typeStr == "float" { // 有符号、无符号整数及浮点数
+		c, ok := compareNumberParam(fieldVal, ruleVal)
+		if !ok || c > 0 {
 			return false
 		}
-	} else if typeStr == "float" { // 浮点
-		val, err := strconv.ParseFloat(ruleVal, 64)
+	} else if typeStr == "array" || typeStr == "map" || typeStr == "chan" { // Array, Slice, Map, Chan统一取长度
+		val, err := strconv.Atoi(ruleVal)
 		if err != nil {
 			return false
 		}
 
-		fVal := fieldVal.Float()
+		length := fieldVal.Len()
 
-		if fVal > float64(val) {
+		if length > val {
 			return false
 		}
-	} else if typeStr == "int" { // 其他默认整型
-		val, err := strconv.ParseInt(ruleVal, 10, 64)
-		if err != nil {
-			return false
+	} else {
+		return false
+	}
+
+	return true
+}
+
+// 比较字段与规则参数的大小，与 range 规则一致：字符串比较字符个数，数字比较数值，数组、切片、map、chan 比较长度
+func (r *Rules) compareSize(ruleVal, fieldType string, fieldVal reflect.Value) (int, bool) {
+	typeStr := getTypeMapping(fieldType)
+	if typeStr == "int" || typeStr == "float" {
+		return compareNumberParam(fieldVal, ruleVal)
+	}
+
+	val, err := strconv.Atoi(ruleVal)
+	if err != nil {
+		return 0, false
+	}
+
+	if typeStr == "string" {
+		return compareInt(int64(utf8.RuneCountInString(fieldVal.String())), int64(val)), true
+	} else if typeStr == "array" || typeStr == "map" || typeStr == "chan" { // Array, Slice, Map, Chan统一取长度
+		return compareInt(int64(fieldVal.Len()), int64(val)), true
+	}
+
+	return 0, false
+}
+
+// Size 验证数据必须等于指定的值，字符串比较字符个数，Array, Chan, Map, Slice类型比较长度
+func (r *Rules) Size(ruleVal, fieldType string, fieldVal reflect.Value) bool {
+	c, ok := r.compareSize(ruleVal, fieldType, fieldVal)
+
+	return ok && c == 0
+}
+
+// Gt 验证数据必须大于指定的值，字符串比较字符个数，Array, Chan, Map, Slice类型比较长度
+func (r *Rules) Gt(ruleVal, fieldType string, fieldVal reflect.Value) bool {
+	c, ok := r.compareSize(ruleVal, fieldType, fieldVal)
+
+	return ok && c > 0
+}
+
+// Gte 验证数据必须大于或等于指定的值，字符串比较字符个数，Array, Chan, Map, Slice类型比较长度
+func (r *Rules) Gte(ruleVal, fieldType string, fieldVal reflect.Value) bool {
+	c, ok := r.compareSize(ruleVal, fieldType, fieldVal)
+
+	return ok && c >= 0
+}
+
+// Lt 验证数据必须小于指定的值，字符串比较字符个数，Array, Chan, Map, Slice类型比较长度
+func (r *Rules) Lt(ruleVal, fieldType string, fieldVal reflect.Value) bool {
+	c, ok := r.compareSize(ruleVal, fieldType, fieldVal)
+
+	return ok && c < 0
+}
+
+// Lte 验证数据必须小于或等于指定的值，字符串比较字符个数，Array, Chan, Map, Slice类型比较长度
+func (r *Rules) Lte(ruleVal, fieldType string, fieldVal reflect.Value) bool {
+	c, ok := r.compareSize(ruleVal, fieldType, fieldVal)
+
+	return ok && c <= 0
+}
+
+// 获取整数或纯数字字符串的数字部分，负数及非数字返回 false
+func (r *Rules) digitsOf(fieldVal reflect.Value) (string, bool) {
+	var str string
+	switch {
+	case isIntKind(fieldVal.Kind()):
+		if fieldVal.Int() < 0 {
+			return "", false
 		}
 
-		iVal := fieldVal.Int()
+		str = strconv.FormatInt(fieldVal.Int(), 10)
+	case isUintKind(fieldVal.Kind()):
+		str = strconv.FormatUint(fieldVal.Uint(), 10)
+	case fieldVal.Kind() == reflect.String:
+		str = fieldVal.String()
+		if !rxNumeric.MatchString(str) {
+			return "", false
+		}
+	default:
+		return "", false
+	}
 
-		if iVal > val {
-			return false
+	return str, true
+}
+
+// Digits 验证数据必须是数字，且长度为指定的位数
+// rule exp "digits:6"
+func (r *Rules) Digits(ruleVal, fieldType string, fieldVal reflect.Value) bool {
+	val, err := strconv.Atoi(ruleVal)
+	if err != nil {
+		return false
+	}
+
+	str, ok := r.digitsOf(fieldVal)
+
+	return ok && len(str) == val
+}
+
+// DigitsBetween 验证数据必须是数字，且位数在给定的 min 和 max 之间
+// rule exp "digits_between:min,max"
+func (r *Rules) DigitsBetween(ruleVal, fieldType string, fieldVal reflect.Value) bool {
+	min, max, ok := r.parseBetween(ruleVal)
+	if !ok {
+		return false
+	}
+
+	str, ok := r.digitsOf(fieldVal)
+
+	return ok && len(str) >= min && len(str) <= max
+}
+
+// NotIn 验证数据不在指定数据中
+func (r *Rules) NotIn(ruleVal, fieldType string, fieldVal reflect.Value) bool {
+	typeStr := getTypeMapping(fieldType)
+	if typeStr != "string" && typeStr != "int" && typeStr != "float" {
+		return false
+	}
+
+	return !r.In(ruleVal, fieldType, fieldVal)
+}
+
+// Integer 验证数据必须是整型，或者是合法的整型字符串
+func (r *Rules) Integer(ruleVal, fieldType string, fieldVal reflect.Value) bool {
+	kind := fieldVal.Kind()
+	if isIntKind(kind) || isUintKind(kind) {
+		return true
+	}
+
+	return kind == reflect.String && rxInt.MatchString(fieldVal.String())
+}
+
+// Boolean 验证数据必须可以转化为布尔值，可接受 true, false, 1, 0, "1", "0", "true", "false"
+func (r *Rules) Boolean(ruleVal, fieldType string, fieldVal reflect.Value) bool {
+	switch kind := fieldVal.Kind(); {
+	case kind == reflect.Bool:
+		return true
+	case isIntKind(kind):
+		return fieldVal.Int() == 0 || fieldVal.Int() == 1
+	case isUintKind(kind):
+		return fieldVal.Uint() == 0 || fieldVal.Uint() == 1
+	case kind == reflect.String:
+		switch fieldVal.String() {
+		case "1", "0", "true", "false":
+			return true
 		}
-	} else if typeStr == "array" || typeStr == "map" || typeStr == "chan" { // Array, Slice, Map, Chan统一取长度
-		val, err := strconv.Atoi(ruleVal)
-		if err != nil {
-			return false
+	}
+
+	return false
+}
+
+// Accepted 验证数据必须是 yes, on, 1, true，常用于验证是否同意服务条款
+func (r *Rules) Accepted(ruleVal, fieldType string, fieldVal reflect.Value) bool {
+	switch kind := fieldVal.Kind(); {
+	case kind == reflect.Bool:
+		return fieldVal.Bool()
+	case isIntKind(kind):
+		return fieldVal.Int() == 1
+	case isUintKind(kind):
+		return fieldVal.Uint() == 1
+	case kind == reflect.String:
+		switch strings.ToLower(fieldVal.String()) {
+		case "yes", "on", "1", "true":
+			return true
 		}
+	}
 
-		length := fieldVal.Len()
+	return false
+}
 
-		if length > val {
-			return false
+// Filled 验证字段存在时不能为空，为空的判断与 required 规则一致，字段不存在时验证通过
+func (r *Rules) Filled(ruleVal, fieldType string, fieldVal reflect.Value) bool {
+	return !isEmptyValue(fieldVal)
+}
+
+// Present 验证字段必须存在，值可以为 nil 或空
+func (r *Rules) Present(ruleVal, fieldType string, fieldVal reflect.Value) bool {
+	return fieldVal.IsValid()
+}
+
+// Distinct 验证数组、切片中的元素或 map 中的值不能重复
+func (r *Rules) Distinct(ruleVal, fieldType string, fieldVal reflect.Value) bool {
+	var values []reflect.Value
+	switch fieldVal.Kind() {
+	case reflect.Array, reflect.Slice:
+		for i := 0; i < fieldVal.Len(); i++ {
+			values = append(values, fieldVal.Index(i))
 		}
-	} else {
+	case reflect.Map:
+		iter := fieldVal.MapRange()
+		for iter.Next() {
+			values = append(values, iter.Value())
+		}
+	default:
 		return false
 	}
 
+	for i := 0; i < len(values); i++ {
+		for j := i + 1; j < len(values); j++ {
+			if sameValue(values[i], values[j]) {
+				return false
+			}
+		}
+	}
+
 	return true
 }
 
+// 解析 min,max 格式的规则参数
+func (r *Rules) parseBetween(ruleVal string) (int, int, bool) {
+	strArr := strings.Split(ruleVal, ",")
+	if len(strArr) != 2 {
+		return 0, 0, false
+	}
+
+	min, minErr := strconv.Atoi(strings.TrimSpace(strArr[0]))
+	max, maxErr := strconv.Atoi(strings.TrimSpace(strArr[1]))
+	if minErr != nil || maxErr != nil {
+		return 0, 0, false
+	}
+
+	return min, max, true
+}
+
+// 解析 min,max 或 max 格式的规则参数，只有一个参数时 min 为 0
+func (r *Rules) parseLimit(ruleVal string) (int, int, bool) {
+	if maxOnlyParam(ruleVal) {
+		max, err := strconv.Atoi(strings.TrimSpace(ruleVal))
+		return 0, max, err == nil
+	}
+
+	return r.parseBetween(ruleVal)
+}
+
+// Bytes 验证字符串的字节数必须在给定的 min 和 max 之间，按 UTF-8 编码计算，常用于限制数据库字段长度
+// 只有一个参数时为最大字节数，rule exp "bytes:0,30" 或 "bytes:30"
+func (r *Rules) Bytes(ruleVal, fieldType string, fieldVal reflect.Value) bool {
+	min, max, ok := r.parseLimit(ruleVal)
+	if !ok || fieldVal.Kind() != reflect.String {
+		return false
+	}
+
+	length := len(fieldVal.String())
+
+	return length >= min && length <= max
+}
+
+// Width 验证字符串的显示宽度必须在给定的 min 和 max 之间，全角及中日韩等宽字符计为 2，组合字符计为 0
+// 只有一个参数时为最大宽度，rule exp "width:2,16" 或 "width:16"
+func (r *Rules) Width(ruleVal, fieldType string, fieldVal reflect.Value) bool {
+	min, max, ok := r.parseLimit(ruleVal)
+	if !ok || fieldVal.Kind() != reflect.String {
+		return false
+	}
+
+	width := stringWidth(fieldVal.String())
+
+	return width >= min && width <= max
+}
+
 // Email 验证字段是否是合法邮箱地址
 func (r *Rules) Email(ruleVal, fieldType string, fieldVal reflect.Value) bool {
 	str, err := r.getStr(fieldType, fieldVal)
@@ -342,15 +545,14 @@ func (r *Rules) CnTel(ruleVal, fieldType string, fieldVal reflect.Value) bool {
 
 }
 
-// Required 字段是否必须，目前支持字符串
+// Required 字段不能为空，不存在、nil、空字符串、空集合及零值均验证不通过
+// 指针字段及 map 数据中的值不为 nil 时视为已填写，零值验证通过，只有空字符串及空集合验证不通过
 func (r *Rules) Required(ruleVal, fieldType string, fieldVal reflect.Value) bool {
-	if fieldType == "string" {
-		str := fieldVal.String()
-		if r.IsNull(str) {
-			return false
-		}
-	}
+	return !isEmptyValue(fieldVal)
+}
 
+// Nullable 字段可以为 nil，为 nil 时跳过其余规则
+func (r *Rules) Nullable(ruleVal, fieldType string, fieldVal reflect.Value) bool {
 	return true
 }
 
@@ -359,6 +561,11 @@ func (r *Rules) Sometimes(ruleVal, fieldType string, fieldVal reflect.Value) boo
 	return true
 }
 
+// Bail 字段第一个规则验证失败后，跳过其余规则
+func (r *Rules) Bail(ruleVal, fieldType string, fieldVal reflect.Value) bool {
+	return true
+}
+
 // IsHexadecimal 验证是否是合法的16进制数据.
 func (r *Rules) IsHexadecimal(ruleVal, fieldType string, fieldVal reflect.Value) bool {
 	str, err := r.getStr(fieldType, fieldVal)
@@ -561,7 +768,7 @@ func (r *Rules) IsDataURI(ruleVal, fieldType string, fieldVal reflect.Value) boo
 	}
 
 	dataURI := strings.Split(str, ",")
-	if !rxDataURI.MatchString(dataURI[0]) {
+	if len(dataURI) != 2 || !rxDataURI.MatchString(dataURI[0]) {
 		return false
 	}
 	return r.IsBase64(ruleVal, fieldType, reflect.ValueOf(dataURI[1]))
@@ -673,8 +880,11 @@ func (r *Rules) IsPort(ruleVal, fieldType string, fieldVal reflect.Value) bool {
 		}
 
 		port = val
-	} else if fieldType == "int" {
-		port = fieldVal.Int()
+	} else if getTypeMapping(fieldType) == "int" { // 有符号、无符号整数
+		minC, minOk := compareNumberParam(fieldVal, "1")
+		maxC, maxOk := compareNumberParam(fieldVal, "65535")
+
+		return minOk && maxOk && minC >= 0 && maxC <= 0
 	}
 
 	if port > 0 && port < 65536 {
@@ -0,0 +1,191 @@
+package validator
+
+import (
+	"reflect"
+	"strings"
+	"time"
+)
+
+// 未指定 date_format 时，字符串日期依次尝试的格式
+var dateLayouts = []string{
+	time.RFC3339Nano,
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+	"2006/01/02 15:04:05",
+	"2006/01/02 15:04",
+	"2006/01/02",
+	"20060102",
+}
+
+// strftime 格式到 Go 时间格式的映射
+var strftimeLayouts = map[byte]string{
+	'Y': "2006",
+	'y': "06",
+	'm': "01",
+	'd': "02",
+	'e': "_2",
+	'j': "002",
+	'H': "15",
+	'I': "03",
+	'M': "04",
+	'S': "05",
+	'p': "PM",
+	'b': "Jan",
+	'B': "January",
+	'a': "Mon",
+	'A': "Monday",
+	'z': "-0700",
+	'Z': "MST",
+	'F': "2006-01-02",
+	'T': "15:04:05",
+	'D': "01/02/06",
+	'%': "%",
+}
+
+// 验证字段是否是合法的日期，字段可以是字符串或 time.Time
+// 字段同时使用了 date_format 规则时，按该格式解析
+func ruleDate(fc *FieldContext) error {
+	if _, ok := fc.dateValue(fc.Value); !ok {
+		return ErrInvalid
+	}
+
+	return nil
+}
+
+// 验证字段是否符合指定的日期格式，格式可以是 Go 时间格式或 strftime 格式
+// rule exp "date_format:2006-01-02 15:04" 或 "date_format:%Y-%m-%d %H:%M"
+func ruleDateFormat(fc *FieldContext) error {
+	val := indirectValue(fc.Value)
+	if _, ok := timeOf(val); ok {
+		return nil
+	}
+
+	if val.Kind() != reflect.String {
+		return ErrInvalid
+	}
+
+	if _, err := time.ParseInLocation(goLayout(fc.Param), val.String(), time.Local); err != nil {
+		return ErrInvalid
+	}
+
+	return nil
+}
+
+// 与指定日期比较，参数可以是 now、today、tomorrow、yesterday、日期字符串或其他字段名称
+// rule exp "after:today"、"before:2030-01-01"、"after_or_equal:StartAt"
+func compareDateRule(pass func(c int) bool) RuleFunc {
+	return func(fc *FieldContext) error {
+		t, ok := fc.dateValue(fc.Value)
+		if !ok {
+			return ErrInvalid
+		}
+
+		other, ok := fc.dateParam(fc.Param)
+		if !ok {
+			return ErrInvalid
+		}
+
+		if !pass(compareInt(t.UnixNano(), other.UnixNano())) {
+			return ErrInvalid
+		}
+
+		return nil
+	}
+}
+
+// 获取字段的日期值，字符串优先按 date_format 规则的格式解析
+func (fc *FieldContext) dateValue(val reflect.Value) (time.Time, bool) {
+	val = indirectValue(val)
+	if t, ok := timeOf(val); ok {
+		return t, true
+	}
+
+	if val.Kind() != reflect.String {
+		return time.Time{}, false
+	}
+
+	return parseDate(val.String(), fc.dateLayouts())
+}
+
+// 解析日期规则的参数
+func (fc *FieldContext) dateParam(param string) (time.Time, bool) {
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	switch strings.ToLower(param) {
+	case "now":
+		return now, true
+	case "today":
+		return today, true
+	case "tomorrow":
+		return today.AddDate(0, 0, 1), true
+	case "yesterday":
+		return today.AddDate(0, 0, -1), true
+	}
+
+	if t, ok := parseDate(param, fc.dateLayouts()); ok {
+		return t, true
+	}
+
+	if other, ok := fc.Sibling(param); ok {
+		return fc.dateValue(other)
+	}
+
+	return time.Time{}, false
+}
+
+// 字段可用的日期格式，date_format 规则指定的格式优先
+func (fc *FieldContext) dateLayouts() []string {
+	for _, item := range fc.rules {
+		if item.name == "date_format" {
+			return append([]string{goLayout(item.param)}, dateLayouts...)
+		}
+	}
+
+	return dateLayouts
+}
+
+// 按给定的格式依次尝试解析日期
+func parseDate(str string, layouts []string) (time.Time, bool) {
+	for _, layout := range layouts {
+		if t, err := time.ParseInLocation(layout, str, time.Local); err == nil {
+			return t, true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+// 获取 time.Time 的值
+func timeOf(val reflect.Value) (time.Time, bool) {
+	if val.IsValid() && val.Type() == reflect.TypeOf(time.Time{}) && val.CanInterface() {
+		return val.Interface().(time.Time), true
+	}
+
+	return time.Time{}, false
+}
+
+// 日期格式转换为 Go 时间格式，包含 % 时按 strftime 格式转换
+func goLayout(format string) string {
+	if !strings.Contains(format, "%") {
+		return format
+	}
+
+	var layout strings.Builder
+	for i := 0; i < len(format); i++ {
+		if format[i] == '%' && i+1 < len(format) {
+			if std, ok := strftimeLayouts[format[i+1]]; ok {
+				layout.WriteString(std)
+				i++
+				continue
+			}
+		}
+
+		layout.WriteByte(format[i])
+	}
+
+	return layout.String()
+}
@@ -0,0 +1,101 @@
+package validator
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDateRules(t *testing.T) {
+	now := time.Now()
+	past := time.Date(2020, 6, 1, 12, 0, 0, 0, time.Local)
+	future := now.AddDate(1, 0, 0)
+
+	tests := []struct {
+		name  string
+		rule  string
+		value interface{}
+		pass  bool
+	}{
+		{"date string", "date", "2024-02-29", true},
+		{"date time string", "date", "2024-02-29 10:20:30", true},
+		{"date invalid", "date", "2023-02-29", false},
+		{"date time.Time", "date", past, true},
+		{"date pointer", "date", &past, true},
+		{"date not a date", "date", 20240229, false},
+		{"go layout", "date_format:'02/01/2006 15:04'", "29/02/2024 10:20", true},
+		{"go layout mismatch", "date_format:2006-01-02", "2024/02/29", false},
+		{"strftime", "date_format:%Y%m%d", "20240229", true},
+		{"strftime mismatch", "date_format:%d.%m.%Y", "2024-02-29", false},
+		{"strftime with date", "date_format:%d.%m.%Y|date", "29.02.2024", true},
+		{"after literal", "after:2020-01-01", "2020-01-02", true},
+		{"after equal", "after:2020-01-01", "2020-01-01", false},
+		{"after_or_equal equal", "after_or_equal:2020-01-01", "2020-01-01", true},
+		{"before literal", "before:2020-01-01", past, false},
+		{"before_or_equal", "before_or_equal:2020-06-01 12:00:00", past, true},
+		{"after today", "after:today", future, true},
+		{"before now", "before:now", past, true},
+		{"after tomorrow", "after:tomorrow", now, false},
+		{"after yesterday", "after:yesterday", now, true},
+		{"format applied to comparison", "date_format:%d.%m.%Y|after:01.01.2020", "02.01.2020", true},
+		{"invalid param", "after:someday", past, false},
+	}
+
+	for _, tt := range tests {
+		err := New().AddRule("Day", "", tt.rule, tt.value).Validate()
+		if (err == nil) != tt.pass {
+			t.Errorf("%s: %s with %v: got %v, want pass=%v", tt.name, tt.rule, tt.value, err, tt.pass)
+		}
+	}
+}
+
+type dateEvent struct {
+	StartAt time.Time
+	Begin   string
+	EndAt   time.Time `valid:"after:StartAt"`
+	Finish  string    `valid:"after_or_equal:Begin"`
+}
+
+func TestDateFieldComparison(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		name  string
+		event dateEvent
+		want  []string
+	}{
+		{"valid", dateEvent{StartAt: start, EndAt: start.Add(time.Second), Begin: "2024-01-01", Finish: "2024-01-01"}, nil},
+		{"invalid", dateEvent{StartAt: start, EndAt: start, Begin: "2024-01-02", Finish: "2024-01-01"}, []string{"dateEvent.EndAt.after", "dateEvent.Finish.after_or_equal"}},
+	}
+
+	for _, tt := range tests {
+		if got := errorKeys(New().Struct(tt.event).Validate()); !equalKeys(got, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestDateMessages(t *testing.T) {
+	tests := []struct {
+		rule    string
+		value   interface{}
+		message string
+	}{
+		{"date", "x", "The Day is not a valid date."},
+		{"date_format:%Y-%m-%d", "x", "The Day does not match the format %Y-%m-%d."},
+		{"after:2020-01-01", "2019-01-01", "The Day must be a date after 2020-01-01."},
+		{"before_or_equal:2020-01-01", "2021-01-01", "The Day must be a date before or equal to 2020-01-01."},
+	}
+
+	for _, tt := range tests {
+		err := New().AddRule("Day", "string", tt.rule, tt.value).Validate()
+		errs, ok := err.(ValidationErrors)
+		if !ok {
+			t.Errorf("%s: got %v", tt.rule, err)
+			continue
+		}
+
+		if errs[0].Message != tt.message {
+			t.Errorf("%s: got %q, want %q", tt.rule, errs[0].Message, tt.message)
+		}
+	}
+}
@@ -0,0 +1,309 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// 需要字段上下文的内置验证规则，如关联其他字段的规则、日期规则
+var builtinFieldRules = map[string]RuleFunc{
+	"same":      ruleSame,
+	"different": ruleDifferent,
+	"confirmed": ruleConfirmed,
+	"gt_field":  compareFieldRule(func(c int) bool { return c > 0 }),
+	"gte_field": compareFieldRule(func(c int) bool { return c >= 0 }),
+	"lt_field":  compareFieldRule(func(c int) bool { return c < 0 }),
+	"lte_field": compareFieldRule(func(c int) bool { return c <= 0 }),
+
+	"required_if":          ruleRequiredIf,
+	"required_unless":      ruleRequiredUnless,
+	"required_with":        requiredWithRule(false, false),
+	"required_with_all":    requiredWithRule(true, false),
+	"required_without":     requiredWithRule(false, true),
+	"required_without_all": requiredWithRule(true, true),
+
+	"date":            ruleDate,
+	"date_format":     ruleDateFormat,
+	"after":           compareDateRule(func(c int) bool { return c > 0 }),
+	"after_or_equal":  compareDateRule(func(c int) bool { return c >= 0 }),
+	"before":          compareDateRule(func(c int) bool { return c < 0 }),
+	"before_or_equal": compareDateRule(func(c int) bool { return c <= 0 }),
+}
+
+// 内置字段关联规则最少的参数个数，解析规则时检查
+var minRuleParams = map[string]int{
+	"same":                 1,
+	"different":            1,
+	"gt_field":             1,
+	"gte_field":            1,
+	"lt_field":             1,
+	"lte_field":            1,
+	"required_if":          2,
+	"required_unless":      2,
+	"required_with":        1,
+	"required_with_all":    1,
+	"required_without":     1,
+	"required_without_all": 1,
+}
+
+// 检查规则的参数个数，参数不足时返回错误，如 required_if 需要字段名称及至少一个值
+func checkRuleParams(items []*ruleItem) error {
+	for _, item := range items {
+		min, ok := minRuleParams[item.name]
+		if !ok {
+			continue
+		}
+
+		count := 0
+		if item.param != STR_NULL {
+			for _, param := range strings.Split(item.param, ",") {
+				if strings.TrimSpace(param) != "" {
+					count++
+				}
+			}
+		}
+
+		if count < min {
+			want := "a parameter"
+			if min > 1 {
+				want = strconv.Itoa(min) + " parameters"
+			}
+
+			return errors.New("rule error: " + item.name + " requires at least " + want + ".")
+		}
+	}
+
+	return nil
+}
+
+// 验证字段的值必须与指定字段相同
+// rule exp "same:Password"
+func ruleSame(fc *FieldContext) error {
+	other, ok := fc.Sibling(fc.Param)
+	if !ok || !sameValue(fc.Value, other) {
+		return ErrInvalid
+	}
+
+	return nil
+}
+
+// 验证字段的值必须与指定字段不同
+// rule exp "different:OldPassword"
+func ruleDifferent(fc *FieldContext) error {
+	other, ok := fc.Sibling(fc.Param)
+	if ok && sameValue(fc.Value, other) {
+		return ErrInvalid
+	}
+
+	return nil
+}
+
+// 验证字段必须有一个匹配的确认字段，确认字段默认为 字段名Confirmation（结构体）或 字段名_confirmation（map）
+// 也可以通过参数指定确认字段，rule exp "confirmed" 或 "confirmed:RepeatPassword"
+func ruleConfirmed(fc *FieldContext) error {
+	names := []string{fc.Param}
+	if fc.Param == "" {
+		name := fc.Field
+		if pos := strings.LastIndex(name, "."); pos != -1 {
+			name = name[pos+1:]
+		}
+
+		names = []string{name + "Confirmation", name + "_confirmation"}
+	}
+
+	for _, name := range names {
+		if other, ok := fc.Sibling(name); ok {
+			if sameValue(fc.Value, other) {
+				return nil
+			}
+
+			break
+		}
+	}
+
+	return ErrInvalid
+}
+
+// 与指定字段比较大小，比较方式见 compareValue
+// rule exp "gt_field:StartAt"
+func compareFieldRule(pass func(c int) bool) RuleFunc {
+	return func(fc *FieldContext) error {
+		other, ok := fc.Sibling(fc.Param)
+		if !ok {
+			return ErrInvalid
+		}
+
+		c, ok := compareValue(fc.Value, other)
+		if !ok || !pass(c) {
+			return ErrInvalid
+		}
+
+		return nil
+	}
+}
+
+// 指定字段的值等于任一给定值时，字段不能为空
+// rule exp "required_if:PayType,card,alipay"
+func ruleRequiredIf(fc *FieldContext) error {
+	other, _ := fc.Sibling(fc.Params[0])
+	if inValues(other, fc.Params[1:]) && isEmptyValue(fc.Value) {
+		return ErrInvalid
+	}
+
+	return nil
+}
+
+// 指定字段的值不等于任一给定值时，字段不能为空
+// rule exp "required_unless:PayType,cash"
+func ruleRequiredUnless(fc *FieldContext) error {
+	other, _ := fc.Sibling(fc.Params[0])
+	if !inValues(other, fc.Params[1:]) && isEmptyValue(fc.Value) {
+		return ErrInvalid
+	}
+
+	return nil
+}
+
+// 根据其他字段是否存在判断字段是否必须
+// all 为 false 时任一字段满足条件即可，为 true 时需要所有字段都满足条件；without 为 true 时条件为字段不存在
+// rule exp "required_with:Email,Mobile"
+func requiredWithRule(all, without bool) RuleFunc {
+	return func(fc *FieldContext) error {
+		matched := 0
+		for _, name := range fc.Params {
+			other, ok := fc.Sibling(name)
+			if present := ok && !isBlank(other); present != without {
+				matched++
+			}
+		}
+
+		required := matched > 0
+		if all {
+			required = matched == len(fc.Params)
+		}
+
+		if required && isEmptyValue(fc.Value) {
+			return ErrInvalid
+		}
+
+		return nil
+	}
+}
+
+// 值是否为空：不存在、nil、空字符串以及长度为 0 的数组、切片、map
+func isBlank(val reflect.Value) bool {
+	val = indirectValue(val)
+	if !val.IsValid() {
+		return true
+	}
+
+	switch val.Kind() {
+	case reflect.String, reflect.Array, reflect.Slice, reflect.Map, reflect.Chan:
+		return val.Len() == 0
+	}
+
+	return false
+}
+
+// 值转换为字符串后是否等于任一给定值
+func inValues(val reflect.Value, values []string) bool {
+	str, ok := valueString(val)
+	if !ok {
+		return false
+	}
+
+	for _, value := range values {
+		if str == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+// 值转换为字符串，不存在或无法获取时返回 false
+func valueString(val reflect.Value) (string, bool) {
+	val = indirectValue(val)
+	if !val.IsValid() {
+		return "", false
+	}
+
+	if val.Kind() == reflect.String {
+		return val.String(), true
+	}
+
+	if !val.CanInterface() {
+		return "", false
+	}
+
+	return fmt.Sprint(val.Interface()), true
+}
+
+// 两个值是否相同，数字按数值比较，time.Time 按时间比较
+func sameValue(a, b reflect.Value) bool {
+	a, b = indirectValue(a), indirectValue(b)
+	if !a.IsValid() || !b.IsValid() {
+		return !a.IsValid() && !b.IsValid()
+	}
+
+	if c, ok := compareNumber(a, b); ok {
+		return c == 0
+	}
+
+	if ta, ok := timeOf(a); ok {
+		tb, ok := timeOf(b)
+		return ok && ta.Equal(tb)
+	}
+
+	if !a.CanInterface() || !b.CanInterface() {
+		return false
+	}
+
+	return reflect.DeepEqual(a.Interface(), b.Interface())
+}
+
+// 比较两个值的大小，a 小于、等于、大于 b 时分别返回 -1、0、1
+// 数字比较数值，time.Time 比较时间，字符串比较字符个数，数组、切片、map 比较长度，与 range 规则一致
+func compareValue(a, b reflect.Value) (int, bool) {
+	a, b = indirectValue(a), indirectValue(b)
+	if !a.IsValid() || !b.IsValid() {
+		return 0, false
+	}
+
+	if c, ok := compareNumber(a, b); ok {
+		return c, true
+	}
+
+	if ta, ok := timeOf(a); ok {
+		tb, ok := timeOf(b)
+		if !ok {
+			return 0, false
+		}
+
+		return compareInt(ta.UnixNano(), tb.UnixNano()), true
+	}
+
+	la, okA := lengthOf(a)
+	lb, okB := lengthOf(b)
+	if !okA || !okB {
+		return 0, false
+	}
+
+	return compareInt(int64(la), int64(lb)), true
+}
+
+// 获取字符串的字符个数或数组、切片、map、chan 的长度
+func lengthOf(val reflect.Value) (int, bool) {
+	switch val.Kind() {
+	case reflect.String:
+		return utf8.RuneCountInString(val.String()), true
+	case reflect.Array, reflect.Slice, reflect.Map, reflect.Chan:
+		return val.Len(), true
+	}
+
+	return 0, false
+}
@@ -0,0 +1,235 @@
+package validator
+
+import (
+	"testing"
+	"time"
+)
+
+type fieldPeriod struct {
+	StartAt time.Time
+	Days    int
+}
+
+type fieldRegister struct {
+	Password             string `valid:"required|confirmed"`
+	PasswordConfirmation string
+	OldPassword          string `valid:"different:Password"`
+	Repeat               string `valid:"same:fieldRegister.Password"`
+	Period               fieldPeriod
+	EndAt                time.Time `valid:"gt_field:Period.StartAt"`
+	MaxDays              int       `valid:"gte_field:Period.Days"`
+	MinDays              uint8     `valid:"lt_field:MaxDays"`
+	Short                string    `valid:"lte_field:Password"`
+}
+
+func TestCrossFieldRules(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	valid := fieldRegister{
+		Password:             "secret",
+		PasswordConfirmation: "secret",
+		OldPassword:          "old",
+		Repeat:               "secret",
+		Period:               fieldPeriod{StartAt: start, Days: 3},
+		EndAt:                start.Add(time.Hour),
+		MaxDays:              3,
+		MinDays:              2,
+		Short:                "abc",
+	}
+
+	tests := []struct {
+		name   string
+		modify func(r *fieldRegister)
+		want   []string
+	}{
+		{"valid", func(r *fieldRegister) {}, nil},
+		{"confirmed", func(r *fieldRegister) { r.PasswordConfirmation = "other" }, []string{"fieldRegister.Password.confirmed"}},
+		{"different", func(r *fieldRegister) { r.OldPassword = "secret" }, []string{"fieldRegister.OldPassword.different"}},
+		{"same with root path", func(r *fieldRegister) { r.Repeat = "x" }, []string{"fieldRegister.Repeat.same"}},
+		{"gt_field time", func(r *fieldRegister) { r.EndAt = start }, []string{"fieldRegister.EndAt.gt_field"}},
+		{"gte_field number", func(r *fieldRegister) { r.MaxDays = 2; r.MinDays = 1 }, []string{"fieldRegister.MaxDays.gte_field"}},
+		{"lt_field mixed kinds", func(r *fieldRegister) { r.MinDays = 3 }, []string{"fieldRegister.MinDays.lt_field"}},
+		{"lte_field length", func(r *fieldRegister) { r.Short = "abcdefg" }, []string{"fieldRegister.Short.lte_field"}},
+	}
+
+	for _, tt := range tests {
+		r := valid
+		tt.modify(&r)
+
+		if got := errorKeys(New().Struct(&r).Validate()); !equalKeys(got, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestCrossFieldMapRules(t *testing.T) {
+	rules := map[string][]string{
+		"password":     {"string", "required|confirmed"},
+		"old_password": {"string", "different:password"},
+		"max":          {"int", "gt_field:min"},
+	}
+
+	tests := []struct {
+		name string
+		data map[string]interface{}
+		want []string
+	}{
+		{"valid", map[string]interface{}{"password": "a", "password_confirmation": "a", "old_password": "b", "min": 1, "max": 2}, nil},
+		{"invalid", map[string]interface{}{"password": "a", "old_password": "a", "min": 2, "max": 2}, []string{"max.gt_field", "old_password.different", "password.confirmed"}},
+	}
+
+	for _, tt := range tests {
+		if got := errorKeys(New().AddMapRule(rules, tt.data).Validate()); !equalKeys(got, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestCrossFieldMessages(t *testing.T) {
+	r := fieldRegister{Password: "a", PasswordConfirmation: "a", OldPassword: "a", Repeat: "a"}
+
+	err := New().Struct(&r).Validate()
+	errs, ok := err.(ValidationErrors)
+	if !ok || len(errs) == 0 {
+		t.Fatalf("got %v", err)
+	}
+
+	if want := "The fieldRegister.OldPassword and Password must be different."; errs[0].Message != want {
+		t.Errorf("got %q, want %q", errs[0].Message, want)
+	}
+}
+
+type fieldPayment struct {
+	PayType string
+	CardNo  string `valid:"required_if:PayType,card,credit"`
+	Cash    *int   `valid:"required_unless:PayType,card"`
+	Email   string
+	Mobile  string
+	Contact string   `valid:"required_with:Email,Mobile"`
+	Both    []string `valid:"required_with_all:Email,Mobile"`
+	Backup  string   `valid:"required_without:Email,Mobile"`
+	Any     string   `valid:"required_without_all:Email,Mobile"`
+}
+
+func TestRequiredConditionalRules(t *testing.T) {
+	cash := 0
+
+	tests := []struct {
+		name string
+		obj  fieldPayment
+		want []string
+	}{
+		{
+			"card without contacts",
+			fieldPayment{PayType: "card"},
+			[]string{"fieldPayment.CardNo.required_if", "fieldPayment.Backup.required_without", "fieldPayment.Any.required_without_all"},
+		},
+		{
+			"cash with one contact",
+			fieldPayment{PayType: "cash", Email: "a@b.cn"},
+			[]string{"fieldPayment.Cash.required_unless", "fieldPayment.Contact.required_with", "fieldPayment.Backup.required_without"},
+		},
+		{
+			"cash with all contacts",
+			fieldPayment{PayType: "cash", Cash: &cash, Email: "a@b.cn", Mobile: "1", Contact: "x"},
+			[]string{"fieldPayment.Both.required_with_all"},
+		},
+		{
+			// required_if_xxx 等规则不能被当作 required
+			"all satisfied",
+			fieldPayment{PayType: "credit", CardNo: "1", Email: "a@b.cn", Mobile: "1", Contact: "x", Both: []string{"x"}, Cash: &cash},
+			nil,
+		},
+	}
+
+	for _, tt := range tests {
+		if got := errorKeys(New().Struct(tt.obj).Validate()); !equalKeys(got, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	if New().ContainRequired("required_if:PayType,card") {
+		t.Error("ContainRequired should not match required_if")
+	}
+}
+
+func TestRequiredConditionalMapRules(t *testing.T) {
+	rules := map[string][]string{
+		"card_no": {"string", "required_if:pay_type,card"},
+		"email":   {"string", "required_without:mobile"},
+	}
+
+	tests := []struct {
+		name string
+		data map[string]interface{}
+		want []string
+	}{
+		{"missing", map[string]interface{}{"pay_type": "card"}, []string{"card_no.required_if", "email.required_without"}},
+		{"present", map[string]interface{}{"pay_type": "card", "card_no": "1", "mobile": "1"}, nil},
+		{"not required", map[string]interface{}{"pay_type": "cash", "email": "a@b.cn"}, nil},
+	}
+
+	for _, tt := range tests {
+		if got := errorKeys(New().AddMapRule(rules, tt.data).Validate()); !equalKeys(got, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestRequiredConditionalMessages(t *testing.T) {
+	tests := []struct {
+		rule    string
+		message string
+	}{
+		{"required_if:Type,card,credit", "The Field field is required when Type is card, credit."},
+		{"required_unless:Type,cash,free", "The Field field is required unless Type is in cash, free."},
+		{"required_with:Email,Mobile", "The Field field is required when Email, Mobile is present."},
+	}
+
+	for _, tt := range tests {
+		data := map[string]interface{}{"Type": "card", "Email": "a@b.cn"}
+		err := New().AddMapRule(map[string][]string{"Field": {"string", tt.rule}}, data).Validate()
+		errs, ok := err.(ValidationErrors)
+		if !ok {
+			t.Errorf("%s: got %v", tt.rule, err)
+			continue
+		}
+
+		if errs[0].Message != tt.message {
+			t.Errorf("%s: got %q, want %q", tt.rule, errs[0].Message, tt.message)
+		}
+	}
+}
+
+func TestRuleParamCount(t *testing.T) {
+	tests := []struct {
+		rule    string
+		wantErr string
+	}{
+		{"required_if:Type", "rule error: required_if requires at least 2 parameters."},
+		{"required_unless", "rule error: required_unless requires at least 2 parameters."},
+		{"required_with", "rule error: required_with requires at least a parameter."},
+		{"required_without_all: , ", "rule error: required_without_all requires at least a parameter."},
+		{"same", "rule error: same requires at least a parameter."},
+		{"dive|gt_field", "rule error: gt_field requires at least a parameter."},
+		{"required_if:Type,card", ""},
+	}
+
+	for _, tt := range tests {
+		err := New().AddRule("Field", "string", tt.rule, "x").Validate()
+		if _, ok := err.(ValidationErrors); ok {
+			t.Errorf("%s: got validation errors %v", tt.rule, err)
+			continue
+		}
+
+		if got := errString(err); got != tt.wantErr {
+			t.Errorf("%s: got %q, want %q", tt.rule, got, tt.wantErr)
+		}
+	}
+
+	_, err := Compile(struct {
+		X string `valid:"required_if:Y"`
+	}{})
+	if got := errString(err); got != "rule error: required_if requires at least 2 parameters." {
+		t.Errorf("Compile: got %q", got)
+	}
+}
@@ -0,0 +1,236 @@
+package validator
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// ErrInvalid 自定义验证方法返回该错误时，使用规则对应的默认错误信息
+var ErrInvalid = errors.New("validator: invalid value")
+
+// RuleFunc 自定义验证方法，返回 nil 表示验证通过
+// 返回 ErrInvalid 时使用规则对应的默认错误信息，返回其他错误时使用该错误的信息作为错误信息
+type RuleFunc func(fc *FieldContext) error
+
+// FieldContext 自定义验证方法的字段上下文
+type FieldContext struct {
+	Rule   string        // 规则名称，统一为小写
+	Param  string        // 规则参数，如 range:6,20 中的 6,20，没有参数时为空
+	Params []string      // 按逗号分隔后的规则参数
+	Field  string        // 字段路径，如 User.Name
+	Type   string        // 字段数据类型
+	Kind   reflect.Kind  // 字段值的 Kind
+	Value  reflect.Value // 字段值
+	Parent reflect.Value // 字段所在的结构体或 map 数据，AddRule 添加的字段为无效值
+
+	root    reflect.Value // 最外层的结构体或 map 数据
+	rootKey string        // 最外层数据的名称
+	rules   []*ruleItem   // 字段的全部验证规则
+	v       *Validator
+}
+
+// Sibling 获取同级字段的值，name 可以是字段名称或以 . 分隔的路径，如 Password、Period.StartAt
+// 结构体中按 WithFieldNamer 命名后的名称或字段名查找，map 数据按 key 查找；同级中不存在时从最外层数据查找，
+// 最外层为结构体时路径可以带上类型名称，如 User.Password；AddRule 添加的字段按字段标识查找已添加的字段
+func (fc *FieldContext) Sibling(name string) (reflect.Value, bool) {
+	if val, ok := fc.v.lookupPath(fc.Parent, name); ok {
+		return val, true
+	}
+
+	if fc.root.IsValid() {
+		path := name
+		if fc.rootKey != "" && strings.HasPrefix(path, fc.rootKey+".") {
+			path = path[len(fc.rootKey)+1:]
+		}
+
+		if val, ok := fc.v.lookupPath(fc.root, path); ok {
+			return val, true
+		}
+	}
+
+	if !fc.Parent.IsValid() {
+		if field, ok := fc.v.fieldMap[name]; ok {
+			return field.value, true
+		}
+	}
+
+	return reflect.Value{}, false
+}
+
+// 按以 . 分隔的路径查找结构体字段或 map 的值
+func (v *Validator) lookupPath(val reflect.Value, path string) (reflect.Value, bool) {
+	if path == "" {
+		return reflect.Value{}, false
+	}
+
+	for _, name := range strings.Split(path, ".") {
+		val = indirectValue(val)
+
+		switch val.Kind() {
+		case reflect.Struct:
+			field, ok := v.structField(val, name)
+			if !ok {
+				return reflect.Value{}, false
+			}
+
+			val = field
+		case reflect.Map:
+			if val.Type().Key().Kind() != reflect.String {
+				return reflect.Value{}, false
+			}
+
+			val = val.MapIndex(reflect.ValueOf(name).Convert(val.Type().Key()))
+			if !val.IsValid() {
+				return reflect.Value{}, false
+			}
+		default:
+			return reflect.Value{}, false
+		}
+	}
+
+	if val.Kind() == reflect.Interface && !val.IsNil() {
+		val = val.Elem()
+	}
+
+	return val, true
+}
+
+// 查找结构体字段，先按命名后的名称查找，再按字段名查找
+func (v *Validator) structField(val reflect.Value, name string) (reflect.Value, bool) {
+	if field, ok := findField(val, func(field reflect.StructField) bool { return v.fieldName(field) == name }); ok {
+		return field, true
+	}
+
+	return findField(val, func(field reflect.StructField) bool { return field.Name == name })
+}
+
+// 逐个查找匹配的结构体字段，匿名嵌入字段的子字段与上一级字段同级，嵌入的 nil 指针跳过
+func findField(val reflect.Value, match func(field reflect.StructField) bool) (reflect.Value, bool) {
+	typ := val.Type()
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if match(field) {
+			return val.Field(i), true
+		}
+
+		if field.Anonymous {
+			if embedded := indirectValue(val.Field(i)); embedded.Kind() == reflect.Struct {
+				if sub, ok := findField(embedded, match); ok {
+					return sub, true
+				}
+			}
+		}
+	}
+
+	return reflect.Value{}, false
+}
+
+// 全局自定义验证规则
+var globalRules = struct {
+	sync.RWMutex
+	m map[string]RuleFunc
+}{m: make(map[string]RuleFunc)}
+
+// RegisterRule 注册全局自定义验证规则，所有 Validator 均可使用，可以在多个 goroutine 中调用
+func RegisterRule(name string, fn RuleFunc) error {
+	name, err := checkRuleFunc(name, fn)
+	if err != nil {
+		return err
+	}
+
+	globalRules.Lock()
+	globalRules.m[name] = fn
+	globalRules.Unlock()
+
+	return nil
+}
+
+// RegisterRule 注册当前 Validator 的自定义验证规则，同名时优先于全局规则
+func (v *Validator) RegisterRule(name string, fn RuleFunc) error {
+	name, err := checkRuleFunc(name, fn)
+	if err != nil {
+		return err
+	}
+
+	v.rules[name] = fn
+
+	return nil
+}
+
+// 检查自定义验证规则，返回小写的规则名称
+func checkRuleFunc(name string, fn RuleFunc) (string, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" || fn == nil {
+		return "", errors.New("rule error: RegisterRule requires a name and a func.")
+	}
+
+	if _, _, _, ok := lookupBuiltinRule(name); ok {
+		return "", errors.New("rule error: " + name + " is a built-in rule.")
+	}
+
+	return name, nil
+}
+
+// 检查规则是否均已定义，未定义的规则在添加字段时即返回错误
+// 自定义规则需要在 Struct、AddRule、AddMapRule 之前注册
+func (v *Validator) checkRuleNames(fieldKey string, rules []*ruleItem) error {
+	for _, item := range rules {
+		if item.fn == nil && item.fieldFn == nil && item.name != STR_DIVE {
+			if _, ok := v.lookupRule(item.name); !ok {
+				if _, ok := v.TagMap[item.name]; !ok {
+					return errors.New("rule error: Undefined rule " + item.name + " on " + fieldKey + ".")
+				}
+			}
+		}
+
+		if err := v.checkRuleNames(fieldKey, item.keys); err != nil {
+			return err
+		}
+
+		if err := v.checkRuleNames(fieldKey, item.each); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// 查找自定义验证规则，当前 Validator 的规则优先
+func (v *Validator) lookupRule(name string) (RuleFunc, bool) {
+	if fn, ok := v.rules[name]; ok {
+		return fn, true
+	}
+
+	globalRules.RLock()
+	fn, ok := globalRules.m[name]
+	globalRules.RUnlock()
+
+	return fn, ok
+}
+
+// 创建字段上下文
+func (v *Validator) newFieldContext(field *fieldData, item *ruleItem) *FieldContext {
+	fc := &FieldContext{
+		Rule:    strings.ToLower(item.name),
+		Field:   field.key,
+		Type:    field.fieldType,
+		Kind:    field.value.Kind(),
+		Value:   field.value,
+		Parent:  field.parent,
+		root:    field.root,
+		rootKey: field.rootKey,
+		rules:   field.rules,
+		v:       v,
+	}
+
+	if item.param != STR_NULL {
+		fc.Param = item.param
+		for _, param := range strings.Split(item.param, ",") {
+			fc.Params = append(fc.Params, strings.TrimSpace(param))
+		}
+	}
+
+	return fc
+}
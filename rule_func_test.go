@@ -0,0 +1,158 @@
+package validator
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type ruleFuncOrder struct {
+	Min int `valid:"required"`
+	Max int `valid:"test_above:Min,1"`
+}
+
+func TestRegisterRuleContext(t *testing.T) {
+	var got *FieldContext
+
+	v := New()
+	err := v.RegisterRule("Test_Above", func(fc *FieldContext) error {
+		got = fc
+		other, ok := fc.Sibling(fc.Params[0])
+		if !ok || fc.Value.Int() <= other.Int() {
+			return ErrInvalid
+		}
+
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := v.Struct(&ruleFuncOrder{Min: 5, Max: 9}).Validate(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got == nil {
+		t.Fatal("rule was not called")
+	}
+
+	checks := []struct {
+		name      string
+		got, want interface{}
+	}{
+		{"Rule", got.Rule, "test_above"},
+		{"Param", got.Param, "Min,1"},
+		{"Params", strings.Join(got.Params, "|"), "Min|1"},
+		{"Field", got.Field, "ruleFuncOrder.Max"},
+		{"Type", got.Type, "int"},
+		{"Kind", got.Kind, reflect.Int},
+		{"Value", got.Value.Interface(), 9},
+		{"Parent", got.Parent.Type(), reflect.TypeOf(ruleFuncOrder{})},
+	}
+
+	for _, c := range checks {
+		if c.got != c.want {
+			t.Errorf("%s = %v, want %v", c.name, c.got, c.want)
+		}
+	}
+}
+
+func TestRegisterRuleMessages(t *testing.T) {
+	v := New()
+	v.RegisterRule("even", func(fc *FieldContext) error {
+		if fc.Value.Int()%2 != 0 {
+			return ErrInvalid
+		}
+
+		return nil
+	})
+	v.RegisterRule("short", func(fc *FieldContext) error {
+		if fc.Value.Len() > 3 {
+			return errors.New(fc.Field + " is too long")
+		}
+
+		return nil
+	})
+
+	tests := []struct {
+		name    string
+		rule    string
+		value   interface{}
+		message string
+	}{
+		{"pass", "even", 2, ""},
+		{"default message", "even", 3, "The Field is invalid."},
+		{"custom message", "short", "abcd", "Field is too long"},
+	}
+
+	for _, tt := range tests {
+		v.ClearError()
+		err := v.AddRule("Field", "", tt.rule, tt.value).Validate()
+
+		message := ""
+		if errs, ok := err.(ValidationErrors); ok {
+			message = errs[0].Message
+		}
+
+		if message != tt.message {
+			t.Errorf("%s: got %q, want %q", tt.name, message, tt.message)
+		}
+	}
+}
+
+func TestGlobalAndLocalRules(t *testing.T) {
+	if err := RegisterRule("test_global_only", func(fc *FieldContext) error { return ErrInvalid }); err != nil {
+		t.Fatal(err)
+	}
+
+	// 当前验证器的规则优先于全局规则
+	v := New()
+	v.RegisterRule("test_global_only", func(fc *FieldContext) error { return nil })
+
+	if err := v.AddRule("A", "string", "test_global_only", "x").Validate(); err != nil {
+		t.Errorf("local rule should override global rule: %v", err)
+	}
+
+	err := New().AddRule("A", "string", "test_global_only", "x").Validate()
+	if got := errorKeys(err); !equalKeys(got, []string{"A.test_global_only"}) {
+		t.Errorf("global rule: got %v", got)
+	}
+}
+
+func TestRegisterRuleErrors(t *testing.T) {
+	ok := func(fc *FieldContext) error { return nil }
+
+	tests := []struct {
+		name string
+		rule string
+		fn   RuleFunc
+	}{
+		{"empty name", " ", ok},
+		{"nil func", "test_nil", nil},
+		{"builtin", "required", ok},
+		{"builtin alias", "isEmail", ok},
+	}
+
+	for _, tt := range tests {
+		if err := RegisterRule(tt.rule, tt.fn); err == nil {
+			t.Errorf("%s: expected an error", tt.name)
+		}
+
+		if err := New().RegisterRule(tt.rule, tt.fn); err == nil {
+			t.Errorf("%s: expected an error from Validator.RegisterRule", tt.name)
+		}
+	}
+}
+
+func TestLegacyTagMap(t *testing.T) {
+	v := New()
+	v.TagMap["legacy"] = func(args ...reflect.Value) bool {
+		return args[0].String() == "x" && args[2].String() == "ok"
+	}
+
+	err := v.AddRule("A", "string", "legacy:x", "ok").AddRule("B", "string", "legacy:x", "no").Validate()
+	if got := errorKeys(err); !equalKeys(got, []string{"B.legacy"}) {
+		t.Errorf("got %v", got)
+	}
+}
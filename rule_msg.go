@@ -10,12 +10,17 @@ var ruleErrorMsgMap = map[string]interface{}{
 	"after":           "The :attribute must be a date after :date.",
 	"after_or_equal":  "The :attribute must be a date after or equal to :date.",
 	"alpha":           "The :attribute may only contain letters.",
-	"alphadash":       "The :attribute may only contain letters, numbers, dashes and underscores.",
-	"alphanum":        "The :attribute may only contain letters and numbers.",
+	"alpha_dash":      "The :attribute may only contain letters, numbers, dashes and underscores.",
+	"alpha_num":       "The :attribute may only contain letters and numbers.",
 	"array":           "The :attribute must be an array.",
 	"before":          "The :attribute must be a date before :date.",
 	"before_or_equal": "The :attribute must be a date before or equal to :date.",
 	"boolean":         "The :attribute field must be true or false.",
+	"bytes":           "The :attribute must be between :min and :max bytes.",
+	"bytes_max":       "The :attribute may not be greater than :max bytes.",
+	"cn_id_card":      "The :attribute must be a valid ID card number.",
+	"cn_mobile":       "The :attribute must be a valid mobile number.",
+	"cn_tel":          "The :attribute must be a valid telephone number.",
 	"confirmed":       "The :attribute confirmation does not match.",
 	"date":            "The :attribute is not a valid date.",
 	"date_format":     "The :attribute does not match the format :format.",
@@ -46,14 +51,16 @@ var ruleErrorMsgMap = map[string]interface{}{
 		"map":    "The :attribute must have :value items or more.",
 		"chan":   "The :attribute must have :value items or more.",
 	},
-	"image":    "The :attribute must be an image.",
-	"in":       "The selected :attribute is invalid.",
-	"in_array": "The :attribute field does not exist in :other.",
-	"integer":  "The :attribute must be an integer.",
-	"ip":       "The :attribute must be a valid IP address.",
-	"ipv4":     "The :attribute must be a valid IPv4 address.",
-	"ipv6":     "The :attribute must be a valid IPv6 address.",
-	"json":     "The :attribute must be a valid JSON string.",
+	"gt_field":  "The :attribute must be greater than :other.",
+	"gte_field": "The :attribute must be greater than or equal to :other.",
+	"image":     "The :attribute must be an image.",
+	"in":        "The selected :attribute is invalid.",
+	"in_array":  "The :attribute field does not exist in :other.",
+	"integer":   "The :attribute must be an integer.",
+	"ip":        "The :attribute must be a valid IP address.",
+	"ipv4":      "The :attribute must be a valid IPv4 address.",
+	"ipv6":      "The :attribute must be a valid IPv6 address.",
+	"json":      "The :attribute must be a valid JSON string.",
 	"lt": map[string]string{
 		"int":    "The :attribute must be less than :value.",
 		"float":  "The :attribute must be less than :value.",
@@ -63,6 +70,8 @@ var ruleErrorMsgMap = map[string]interface{}{
 		"map":    "The :attribute must have less than :value items.",
 		"chan":   "The :attribute must have less than :value items.",
 	},
+	"lt_field":  "The :attribute must be less than :other.",
+	"lte_field": "The :attribute must be less than or equal to :other.",
 	"lte": map[string]string{
 		"int":    "The :attribute must be less than or equal :value.",
 		"float":  "The :attribute must be less than or equal :value.",
@@ -85,7 +94,7 @@ var ruleErrorMsgMap = map[string]interface{}{
 	"mimetypes": "The :attribute must be a file of type: :values.",
 	"min": map[string]string{
 		"int":    "The :attribute must be at least :value.",
-		"float":  "The :attribute must be at least :value kilobytes.",
+		"float":  "The :attribute must be at least :value.",
 		"string": "The :attribute must be at least :value characters.",
 		"array":  "The :attribute must have at least :value items.",
 		"map":    "The :attribute must have at least :value items.",
@@ -96,13 +105,13 @@ var ruleErrorMsgMap = map[string]interface{}{
 	"numeric":   "The :attribute must be a number.",
 	"present":   "The :attribute field must be present.",
 	"range": map[string]string{
-		"int":    "The :attribute must be between :value.",
-		"float":  "The :attribute must be between :value.",
-		"file":   "The :attribute must be between :value kilobytes.",
-		"string": "The :attribute must be between :value characters.",
-		"array":  "The :attribute must have between :value items.",
-		"map":    "The :attribute must have between :value items.",
-		"chan":   "The :attribute must have between :value items.",
+		"int":    "The :attribute must be between :min and :max.",
+		"float":  "The :attribute must be between :min and :max.",
+		"file":   "The :attribute must be between :min and :max kilobytes.",
+		"string": "The :attribute must be between :min and :max characters.",
+		"array":  "The :attribute must have between :min and :max items.",
+		"map":    "The :attribute must have between :min and :max items.",
+		"chan":   "The :attribute must have between :min and :max items.",
 	},
 	"regex":                "The :attribute format is invalid.",
 	"required":             "The :attribute field is required.",
@@ -114,15 +123,20 @@ var ruleErrorMsgMap = map[string]interface{}{
 	"required_without_all": "The :attribute field is required when none of :values are present.",
 	"same":                 "The :attribute and :other must match.",
 	"size": map[string]string{
-		"numeric": "The :attribute must be :size.",
-		"file":    "The :attribute must be :size kilobytes.",
-		"string":  "The :attribute must be :size characters.",
-		"array":   "The :attribute must contain :size items.",
+		"int":    "The :attribute must be :size.",
+		"float":  "The :attribute must be :size.",
+		"file":   "The :attribute must be :size kilobytes.",
+		"string": "The :attribute must be :size characters.",
+		"array":  "The :attribute must contain :size items.",
+		"map":    "The :attribute must contain :size items.",
+		"chan":   "The :attribute must contain :size items.",
 	},
-	"string":   "The :attribute must be a string.",
-	"timezone": "The :attribute must be a valid zone.",
-	"unique":   "The :attribute has already been taken.",
-	"uploaded": "The :attribute failed to upload.",
-	"url":      "The :attribute format is invalid.",
-	"uuid":     "The :attribute must be a valid UUID.",
+	"string":    "The :attribute must be a string.",
+	"timezone":  "The :attribute must be a valid zone.",
+	"unique":    "The :attribute has already been taken.",
+	"uploaded":  "The :attribute failed to upload.",
+	"url":       "The :attribute format is invalid.",
+	"uuid":      "The :attribute must be a valid UUID.",
+	"width":     "The :attribute must be between :min and :max columns wide.",
+	"width_max": "The :attribute may not be wider than :max columns.",
 }
@@ -0,0 +1,142 @@
+package validator
+
+var zhCNErrorMsgMap = map[string]interface{}{
+	"default":  ":attribute 无效",    // 默认信息
+	"undefine": "验证方法 :func() 未定义", // 验证方法没有定义
+	"null":     ":attribute 字段不存在", // 字段值不存在
+
+	"accepted":        ":attribute 必须接受",
+	"active_url":      ":attribute 不是一个有效的网址",
+	"after":           ":attribute 必须是 :date 之后的日期",
+	"after_or_equal":  ":attribute 必须是 :date 或之后的日期",
+	"alpha":           ":attribute 只能由字母组成",
+	"alpha_dash":      ":attribute 只能由字母、数字、中划线和下划线组成",
+	"alpha_num":       ":attribute 只能由字母和数字组成",
+	"array":           ":attribute 必须是数组",
+	"before":          ":attribute 必须是 :date 之前的日期",
+	"before_or_equal": ":attribute 必须是 :date 或之前的日期",
+	"boolean":         ":attribute 必须为布尔值",
+	"bytes":           ":attribute 必须介于 :min 到 :max 个字节之间",
+	"bytes_max":       ":attribute 不能多于 :max 个字节",
+	"cn_id_card":      ":attribute 不是有效的身份证号码",
+	"cn_mobile":       ":attribute 不是有效的手机号码",
+	"cn_tel":          ":attribute 不是有效的电话号码",
+	"confirmed":       ":attribute 两次输入不一致",
+	"date":            ":attribute 不是有效的日期",
+	"date_format":     ":attribute 的格式必须为 :format",
+	"different":       ":attribute 和 :other 必须不同",
+	"digits":          ":attribute 必须是 :digits 位数字",
+	"digits_between":  ":attribute 必须是介于 :min 和 :max 位的数字",
+	"dimensions":      ":attribute 图片尺寸不正确",
+	"distinct":        ":attribute 存在重复的值",
+	"email":           ":attribute 不是有效的邮箱地址",
+	"exists":          ":attribute 不存在",
+	"file":            ":attribute 必须是文件",
+	"filled":          ":attribute 不能为空",
+	"gt": map[string]string{
+		"int":    ":attribute 必须大于 :value",
+		"float":  ":attribute 必须大于 :value",
+		"file":   ":attribute 必须大于 :value KB",
+		"string": ":attribute 必须多于 :value 个字符",
+		"array":  ":attribute 必须多于 :value 个元素",
+		"map":    ":attribute 必须多于 :value 个元素",
+		"chan":   ":attribute 必须多于 :value 个元素",
+	},
+	"gte": map[string]string{
+		"int":    ":attribute 必须大于或等于 :value",
+		"float":  ":attribute 必须大于或等于 :value",
+		"file":   ":attribute 必须大于或等于 :value KB",
+		"string": ":attribute 必须多于或等于 :value 个字符",
+		"array":  ":attribute 必须多于或等于 :value 个元素",
+		"map":    ":attribute 必须多于或等于 :value 个元素",
+		"chan":   ":attribute 必须多于或等于 :value 个元素",
+	},
+	"gt_field":  ":attribute 必须大于 :other",
+	"gte_field": ":attribute 必须大于或等于 :other",
+	"image":     ":attribute 必须是图片",
+	"in":        "已选的 :attribute 无效",
+	"in_array":  ":attribute 必须在 :other 中",
+	"integer":   ":attribute 必须是整数",
+	"ip":        ":attribute 不是有效的 IP 地址",
+	"ipv4":      ":attribute 不是有效的 IPv4 地址",
+	"ipv6":      ":attribute 不是有效的 IPv6 地址",
+	"json":      ":attribute 不是有效的 JSON 字符串",
+	"lt": map[string]string{
+		"int":    ":attribute 必须小于 :value",
+		"float":  ":attribute 必须小于 :value",
+		"file":   ":attribute 必须小于 :value KB",
+		"string": ":attribute 必须少于 :value 个字符",
+		"array":  ":attribute 必须少于 :value 个元素",
+		"map":    ":attribute 必须少于 :value 个元素",
+		"chan":   ":attribute 必须少于 :value 个元素",
+	},
+	"lt_field":  ":attribute 必须小于 :other",
+	"lte_field": ":attribute 必须小于或等于 :other",
+	"lte": map[string]string{
+		"int":    ":attribute 必须小于或等于 :value",
+		"float":  ":attribute 必须小于或等于 :value",
+		"file":   ":attribute 必须小于或等于 :value KB",
+		"string": ":attribute 必须少于或等于 :value 个字符",
+		"array":  ":attribute 必须少于或等于 :value 个元素",
+		"map":    ":attribute 必须少于或等于 :value 个元素",
+		"chan":   ":attribute 必须少于或等于 :value 个元素",
+	},
+	"max": map[string]string{
+		"int":    ":attribute 不能大于 :value",
+		"float":  ":attribute 不能大于 :value",
+		"file":   ":attribute 不能大于 :value KB",
+		"string": ":attribute 不能多于 :value 个字符",
+		"array":  ":attribute 最多只能有 :value 个元素",
+		"map":    ":attribute 最多只能有 :value 个元素",
+		"chan":   ":attribute 最多只能有 :value 个元素",
+	},
+	"mimes":     ":attribute 必须是 :values 类型的文件",
+	"mimetypes": ":attribute 必须是 :values 类型的文件",
+	"min": map[string]string{
+		"int":    ":attribute 不能小于 :value",
+		"float":  ":attribute 不能小于 :value",
+		"string": ":attribute 至少为 :value 个字符",
+		"array":  ":attribute 至少有 :value 个元素",
+		"map":    ":attribute 至少有 :value 个元素",
+		"chan":   ":attribute 至少有 :value 个元素",
+	},
+	"not_in":    "已选的 :attribute 无效",
+	"not_regex": ":attribute 格式不正确",
+	"numeric":   ":attribute 必须是数字",
+	"present":   ":attribute 必须存在",
+	"range": map[string]string{
+		"int":    ":attribute 必须介于 :min 到 :max 之间",
+		"float":  ":attribute 必须介于 :min 到 :max 之间",
+		"file":   ":attribute 必须介于 :min 到 :max KB 之间",
+		"string": ":attribute 必须介于 :min 到 :max 个字符之间",
+		"array":  ":attribute 必须有 :min 到 :max 个元素",
+		"map":    ":attribute 必须有 :min 到 :max 个元素",
+		"chan":   ":attribute 必须有 :min 到 :max 个元素",
+	},
+	"regex":                ":attribute 格式不正确",
+	"required":             ":attribute 不能为空",
+	"required_if":          "当 :other 为 :value 时 :attribute 不能为空",
+	"required_unless":      "当 :other 不为 :values 时 :attribute 不能为空",
+	"required_with":        "当 :values 存在时 :attribute 不能为空",
+	"required_with_all":    "当 :values 都存在时 :attribute 不能为空",
+	"required_without":     "当 :values 不存在时 :attribute 不能为空",
+	"required_without_all": "当 :values 都不存在时 :attribute 不能为空",
+	"same":                 ":attribute 和 :other 必须相同",
+	"size": map[string]string{
+		"int":    ":attribute 必须等于 :size",
+		"float":  ":attribute 必须等于 :size",
+		"file":   ":attribute 必须为 :size KB",
+		"string": ":attribute 必须为 :size 个字符",
+		"array":  ":attribute 必须为 :size 个元素",
+		"map":    ":attribute 必须为 :size 个元素",
+		"chan":   ":attribute 必须为 :size 个元素",
+	},
+	"string":    ":attribute 必须是字符串",
+	"timezone":  ":attribute 必须是有效的时区",
+	"unique":    ":attribute 已经存在",
+	"uploaded":  ":attribute 上传失败",
+	"url":       ":attribute 格式不正确",
+	"uuid":      ":attribute 不是有效的 UUID",
+	"width":     ":attribute 的显示宽度必须介于 :min 到 :max 之间",
+	"width_max": ":attribute 的显示宽度不能大于 :max",
+}
@@ -0,0 +1,112 @@
+package validator
+
+import (
+	"errors"
+	"reflect"
+	"regexp"
+	"sync"
+)
+
+// regex 规则中可以直接引用的正则表达式名称，对应 patterns.go 中的常量，如 regex:Semver
+var namedPatterns = map[string]string{
+	"Email":          Email,
+	"CreditCard":     CreditCard,
+	"CnIdCard":       CnIdCard,
+	"CnMobile":       CnMobile,
+	"CnTel":          CnTel,
+	"ISBN10":         ISBN10,
+	"ISBN13":         ISBN13,
+	"UUID3":          UUID3,
+	"UUID4":          UUID4,
+	"UUID5":          UUID5,
+	"UUID":           UUID,
+	"Alpha":          Alpha,
+	"AlphaDash":      AlphaDash,
+	"Alphanumeric":   Alphanumeric,
+	"Numeric":        Numeric,
+	"Int":            Int,
+	"Float":          Float,
+	"Hexadecimal":    Hexadecimal,
+	"HexColor":       HexColor,
+	"RGBColor":       RGBColor,
+	"ASCII":          ASCII,
+	"Multibyte":      Multibyte,
+	"FullWidth":      FullWidth,
+	"HalfWidth":      HalfWidth,
+	"Base64":         Base64,
+	"PrintableASCII": PrintableASCII,
+	"DataURI":        DataURI,
+	"Latitude":       Latitude,
+	"Longitude":      Longitude,
+	"DNSName":        DNSName,
+	"IP":             IP,
+	"URL":            URL,
+	"SSN":            SSN,
+	"WinPath":        WinPath,
+	"UnixPath":       UnixPath,
+	"Semver":         Semver,
+}
+
+// 编译后的正则表达式缓存，pattern => *regexp.Regexp
+var regexCache sync.Map
+
+// 获取编译后的正则表达式，pattern 为 namedPatterns 中的名称时使用对应的正则表达式
+func compileRegex(pattern string) (*regexp.Regexp, error) {
+	if cached, ok := regexCache.Load(pattern); ok {
+		return cached.(*regexp.Regexp), nil
+	}
+
+	expr := pattern
+	if named, ok := namedPatterns[pattern]; ok {
+		expr = named
+	}
+
+	rx, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, errors.New("rule error: Invalid regex " + pattern + ": " + err.Error())
+	}
+
+	cached, _ := regexCache.LoadOrStore(pattern, rx)
+
+	return cached.(*regexp.Regexp), nil
+}
+
+// 检查 regex、not_regex 规则的正则表达式，无效的正则表达式在解析规则时即返回错误
+func checkRegexRules(items []*ruleItem) error {
+	for _, item := range items {
+		if item.name != "regex" && item.name != "not_regex" {
+			continue
+		}
+
+		if _, err := compileRegex(item.param); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Regex 验证字符串必须匹配指定的正则表达式
+// rule exp "regex:^[a-z]+$"、"regex:'^(a|b)$'" 或 "regex:Semver"
+func (r *Rules) Regex(ruleVal, fieldType string, fieldVal reflect.Value) bool {
+	str, err := r.getStr(fieldType, fieldVal)
+	if err {
+		return false
+	}
+
+	rx, compileErr := compileRegex(ruleVal)
+
+	return compileErr == nil && rx.MatchString(str)
+}
+
+// NotRegex 验证字符串不能匹配指定的正则表达式
+// rule exp "not_regex:^\\d+$"
+func (r *Rules) NotRegex(ruleVal, fieldType string, fieldVal reflect.Value) bool {
+	if fieldVal.Kind() != reflect.String {
+		return false
+	}
+
+	rx, compileErr := compileRegex(ruleVal)
+
+	return compileErr == nil && !rx.MatchString(fieldVal.String())
+}
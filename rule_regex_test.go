@@ -0,0 +1,95 @@
+package validator
+
+import (
+	"testing"
+)
+
+func TestRegexRules(t *testing.T) {
+	runRuleCases(t, []ruleCase{
+		{"regex:^\\d+$", "123", true},
+		{"regex:^\\d+$", "12a", false},
+		{"regex:'^(stable|beta)$'", "beta", true},
+		{"regex:'^(stable|beta)$'", "alpha", false},
+		{"regex:^(a\\|b)$", "b", true},
+		{"regex:'it''s'", "it's", true},
+		{"regex:'^a:b$'", "a:b", true},
+		{"regex:Semver", "1.2.3", true},
+		{"regex:Semver", "v1.2", false},
+		{"regex:CnMobile", "13800138000", true},
+		{"not_regex:^\\d+$", "abc", true},
+		{"not_regex:^\\d+$", "123", false},
+		{"not_regex:'^(a|b)$'", "a", false},
+		{"regex:^\\d+$", 123, false},
+	})
+}
+
+func TestRegexRuleErrors(t *testing.T) {
+	tests := []struct {
+		rule    string
+		wantErr string
+	}{
+		{"regex:'^(a|b)$", "rule error: Unterminated quoted parameter of regex."},
+		{"regex:'^a$'x|required", "rule error: Unexpected character after quoted parameter of regex."},
+		{"in:'a|b','c'", "rule error: Unexpected character after quoted parameter of in."},
+		{"regex:(", "rule error: Invalid regex (: error parsing regexp: missing closing ): `(`"},
+		{"not_regex:[a", "rule error: Invalid regex [a: error parsing regexp: missing closing ]: `[a`"},
+	}
+
+	for _, tt := range tests {
+		err := New().AddRule("Field", "string", tt.rule, "x").Validate()
+		if got := errString(err); got != tt.wantErr {
+			t.Errorf("%s: got %q, want %q", tt.rule, got, tt.wantErr)
+		}
+	}
+}
+
+func TestRegexCache(t *testing.T) {
+	a, err := compileRegex("^cache-test$")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, _ := compileRegex("^cache-test$")
+	if a != b {
+		t.Error("compiled regex should be cached")
+	}
+
+	c, _ := compileRegex("Semver")
+	if !c.MatchString("1.0.0") {
+		t.Error("named pattern should be resolved")
+	}
+}
+
+func TestTokenizeRule(t *testing.T) {
+	tests := []struct {
+		rules string
+		want  []ruleToken
+	}{
+		{"required|range:6,20", []ruleToken{{"required", STR_NULL}, {"range", "6,20"}}},
+		{" required || email ", []ruleToken{{"required", STR_NULL}, {"email", STR_NULL}}},
+		{"in:'a|b,c'", []ruleToken{{"in", "a|b,c"}}},
+		{"regex: '^a|b$' |bail", []ruleToken{{"regex", "^a|b$"}, {"bail", STR_NULL}}},
+		{"in:a\\|b,c|required", []ruleToken{{"in", "a|b,c"}, {"required", STR_NULL}}},
+		{"date_format:15:04", []ruleToken{{"date_format", "15:04"}}},
+	}
+
+	for _, tt := range tests {
+		got, err := tokenizeRule(tt.rules, defaultSyntax)
+		if err != nil {
+			t.Errorf("%q: %v", tt.rules, err)
+			continue
+		}
+
+		if len(got) != len(tt.want) {
+			t.Errorf("%q: got %v, want %v", tt.rules, got, tt.want)
+			continue
+		}
+
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("%q: got %v, want %v", tt.rules, got, tt.want)
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,222 @@
+package validator
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+)
+
+// 内置验证规则，key 为规则的标准名称，与错误信息中的 key 保持一致
+var builtinRules = map[string]ruleFunc{
+	"required":        (*Rules).Required,
+	"sometimes":       (*Rules).Sometimes,
+	"nullable":        (*Rules).Nullable,
+	"bail":            (*Rules).Bail,
+	"numeric":         (*Rules).Numeric,
+	"range":           (*Rules).Range,
+	"in":              (*Rules).In,
+	"min":             (*Rules).Min,
+	"max":             (*Rules).Max,
+	"email":           (*Rules).Email,
+	"alpha":           (*Rules).Alpha,
+	"alpha_dash":      (*Rules).AlphaDash,
+	"alpha_num":       (*Rules).AlphaNum,
+	"cn_id_card":      (*Rules).CnIdCard,
+	"cn_mobile":       (*Rules).CnMobile,
+	"cn_tel":          (*Rules).CnTel,
+	"hexadecimal":     (*Rules).IsHexadecimal,
+	"hex_color":       (*Rules).IsHexColor,
+	"rgb_color":       (*Rules).IsRGBColor,
+	"lower_case":      (*Rules).IsLowerCase,
+	"upper_case":      (*Rules).IsUpperCase,
+	"has_lower_case":  (*Rules).HasLowerCase,
+	"has_upper_case":  (*Rules).HasUpperCase,
+	"int":             (*Rules).IsInt,
+	"float":           (*Rules).IsFloat,
+	"json":            (*Rules).IsJSON,
+	"multibyte":       (*Rules).IsMultibyte,
+	"ascii":           (*Rules).IsASCII,
+	"printable_ascii": (*Rules).IsPrintableASCII,
+	"full_width":      (*Rules).IsFullWidth,
+	"half_width":      (*Rules).IsHalfWidth,
+	"variable_width":  (*Rules).IsVariableWidth,
+	"base64":          (*Rules).IsBase64,
+	"file_path":       (*Rules).IsFilePath,
+	"data_uri":        (*Rules).IsDataURI,
+	"hash":            (*Rules).IsHash,
+	"dns_name":        (*Rules).IsDNSName,
+	"url":             (*Rules).IsURL,
+	"ip":              (*Rules).IsIP,
+	"port":            (*Rules).IsPort,
+	"ipv4":            (*Rules).IsIPv4,
+	"ipv6":            (*Rules).IsIPv6,
+	"host":            (*Rules).IsHost,
+	"mac":             (*Rules).IsMAC,
+	"ssn":             (*Rules).IsSSN,
+	"uuid_v3":         (*Rules).IsUUIDv3,
+	"uuid_v4":         (*Rules).IsUUIDv4,
+	"uuid_v5":         (*Rules).IsUUIDv5,
+	"uuid":            (*Rules).IsUUID,
+	"size":            (*Rules).Size,
+	"gt":              (*Rules).Gt,
+	"gte":             (*Rules).Gte,
+	"lt":              (*Rules).Lt,
+	"lte":             (*Rules).Lte,
+	"digits":          (*Rules).Digits,
+	"digits_between":  (*Rules).DigitsBetween,
+	"not_in":          (*Rules).NotIn,
+	"integer":         (*Rules).Integer,
+	"boolean":         (*Rules).Boolean,
+	"accepted":        (*Rules).Accepted,
+	"filled":          (*Rules).Filled,
+	"present":         (*Rules).Present,
+	"distinct":        (*Rules).Distinct,
+	"regex":           (*Rules).Regex,
+	"not_regex":       (*Rules).NotRegex,
+	"bytes":           (*Rules).Bytes,
+	"width":           (*Rules).Width,
+}
+
+// 字段不存在时仍需执行的规则
+var implicitRules = map[string]bool{
+	"required":             true,
+	"required_if":          true,
+	"required_unless":      true,
+	"required_with":        true,
+	"required_with_all":    true,
+	"required_without":     true,
+	"required_without_all": true,
+	"present":              true,
+}
+
+// 按数值比较大小的规则，复数类型不能使用
+var numericRules = map[string]bool{
+	"range":     true,
+	"in":        true,
+	"not_in":    true,
+	"min":       true,
+	"max":       true,
+	"size":      true,
+	"gt":        true,
+	"gte":       true,
+	"lt":        true,
+	"lte":       true,
+	"gt_field":  true,
+	"gte_field": true,
+	"lt_field":  true,
+	"lte_field": true,
+}
+
+// 使用字段原始值验证的规则，需要区分不存在、nil 与零值
+func rawValueRules(name string) bool {
+	return implicitRules[name] || name == STR_FILLED
+}
+
+// 内置验证规则的别名，key 为规范化后的别名，value 为标准名称
+// 标准名称本身的各种写法（如 alphaDash、AlphaDash、alpha-dash）通过规范化自动识别
+var ruleAliases = map[string]string{
+	"isrequired":       "required",
+	"isemail":          "email",
+	"isnumeric":        "numeric",
+	"isalpha":          "alpha",
+	"isalphadash":      "alpha_dash",
+	"isalphanum":       "alpha_num",
+	"alphanumeric":     "alpha_num",
+	"iscnidcard":       "cn_id_card",
+	"iscnmobile":       "cn_mobile",
+	"iscntel":          "cn_tel",
+	"ishexadecimal":    "hexadecimal",
+	"ishexcolor":       "hex_color",
+	"isrgbcolor":       "rgb_color",
+	"islowercase":      "lower_case",
+	"isuppercase":      "upper_case",
+	"isint":            "int",
+	"isfloat":          "float",
+	"isjson":           "json",
+	"ismultibyte":      "multibyte",
+	"isascii":          "ascii",
+	"isprintableascii": "printable_ascii",
+	"isfullwidth":      "full_width",
+	"ishalfwidth":      "half_width",
+	"isvariablewidth":  "variable_width",
+	"isbase64":         "base64",
+	"isfilepath":       "file_path",
+	"isdatauri":        "data_uri",
+	"ishash":           "hash",
+	"isdnsname":        "dns_name",
+	"isurl":            "url",
+	"isip":             "ip",
+	"isport":           "port",
+	"isipv4":           "ipv4",
+	"isipv6":           "ipv6",
+	"ishost":           "host",
+	"ismac":            "mac",
+	"isssn":            "ssn",
+	"isuuidv3":         "uuid_v3",
+	"isuuidv4":         "uuid_v4",
+	"isuuidv5":         "uuid_v5",
+	"isuuid":           "uuid",
+	"uuid3":            "uuid_v3",
+	"uuid4":            "uuid_v4",
+	"uuid5":            "uuid_v5",
+	"eqfield":          "same",
+	"nefield":          "different",
+	"bool":             "boolean",
+	"len":              "size",
+}
+
+// 规范化后的名称到标准名称的映射，包含标准名称及别名
+var builtinRuleNames = buildRuleNames()
+
+// 生成规范化名称到标准名称的映射
+func buildRuleNames() map[string]string {
+	names := make(map[string]string, len(builtinRules)+len(builtinFieldRules)+len(ruleAliases))
+	for name := range builtinRules {
+		names[normalizeRuleName(name)] = name
+	}
+
+	for name := range builtinFieldRules {
+		names[normalizeRuleName(name)] = name
+	}
+
+	for alias, name := range ruleAliases {
+		names[alias] = name
+	}
+
+	return names
+}
+
+// 规范化规则名称：转为小写并去除下划线和中划线，如 cn_IdCard、cnIdCard、cn-id-card 均为 cnidcard
+func normalizeRuleName(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	name = strings.Replace(name, "_", "", -1)
+	name = strings.Replace(name, "-", "", -1)
+
+	return name
+}
+
+// 查找内置验证规则，返回规则的标准名称及验证方法，字段关联规则返回 fieldFn
+func lookupBuiltinRule(name string) (canonical string, fn ruleFunc, fieldFn RuleFunc, ok bool) {
+	canonical, ok = builtinRuleNames[normalizeRuleName(name)]
+	if !ok {
+		return "", nil, nil, false
+	}
+
+	return canonical, builtinRules[canonical], builtinFieldRules[canonical], true
+}
+
+// 复数类型的字段不能使用数值比较的规则，绑定字段时即返回错误
+func checkComplex(fieldKey, fieldType string, value reflect.Value, rules []*ruleItem) error {
+	kind := indirectValue(value).Kind()
+	if getTypeMapping(fieldType) != "complex" && kind != reflect.Complex64 && kind != reflect.Complex128 {
+		return nil
+	}
+
+	for _, item := range rules {
+		if numericRules[item.name] {
+			return errors.New("rule error: " + item.name + " does not support complex numbers on " + fieldKey + ".")
+		}
+	}
+
+	return nil
+}
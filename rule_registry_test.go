@@ -0,0 +1,133 @@
+package validator
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRuleNameNormalisation(t *testing.T) {
+	tests := []struct {
+		name      string
+		canonical string
+		ok        bool
+	}{
+		{"cn_IdCard", "cn_id_card", true},
+		{"cnIdCard", "cn_id_card", true},
+		{"cn-id-card", "cn_id_card", true},
+		{"cn_Mobile", "cn_mobile", true},
+		{"isEmail", "email", true},
+		{"AlphaDash", "alpha_dash", true},
+		{"eqfield", "same", true},
+		{"bool", "boolean", true},
+		{"len", "size", true},
+		{"isNull", "", false},
+		{"getStr", "", false},
+		{"nope", "", false},
+	}
+
+	for _, tt := range tests {
+		canonical, _, _, ok := lookupBuiltinRule(tt.name)
+		if canonical != tt.canonical || ok != tt.ok {
+			t.Errorf("%s: got %q %v, want %q %v", tt.name, canonical, ok, tt.canonical, tt.ok)
+		}
+	}
+}
+
+type registryUnknown struct {
+	X string `valid:"nope"`
+}
+
+type registryItem struct {
+	Sku string `valid:"required|test_registry_unknown"`
+}
+
+type registryNested struct {
+	Items []*registryItem `valid:"dive"`
+}
+
+type registryLocal struct {
+	X string `valid:"test_registry_local"`
+}
+
+type registryGlobal struct {
+	X string `valid:"required|test_registry_global"`
+}
+
+func TestCompileUndefinedRules(t *testing.T) {
+	if err := RegisterRule("test_registry_global", func(fc *FieldContext) error { return nil }); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		obj     interface{}
+		wantErr string
+	}{
+		{"unknown", registryUnknown{}, "rule error: Undefined rule nope on registryUnknown.X."},
+		{"dive element", registryNested{}, "rule error: Undefined rule test_registry_unknown on registryNested.Items.Sku."},
+		{"local only", registryLocal{}, "rule error: Undefined rule test_registry_local on registryLocal.X."},
+		{"global", registryGlobal{}, ""},
+		{"builtin aliases", struct {
+			X string `valid:"cn_Mobile|isEmail"`
+		}{}, ""},
+	}
+
+	for _, tt := range tests {
+		_, err := Compile(tt.obj)
+		if got := errString(err); got != tt.wantErr {
+			t.Errorf("%s: got %q, want %q", tt.name, got, tt.wantErr)
+		}
+	}
+}
+
+func TestValidatorCompileLocalRules(t *testing.T) {
+	v := New()
+	if _, err := v.Compile(registryLocal{}); err == nil {
+		t.Error("expected an error before the rule is registered")
+	}
+
+	v.RegisterRule("test_registry_local", func(fc *FieldContext) error { return ErrInvalid })
+	schema, err := v.Compile(registryLocal{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = v.StructWithSchema(schema, registryLocal{}).Validate()
+	if got := errorKeys(err); !equalKeys(got, []string{"registryLocal.X.test_registry_local"}) {
+		t.Errorf("got %v", got)
+	}
+
+	// 其他验证器没有注册该规则
+	err = New().StructWithSchema(schema, registryLocal{}).Validate()
+	if got := errString(err); got != "rule error: Undefined rule test_registry_local on registryLocal.X." {
+		t.Errorf("got %q", got)
+	}
+}
+
+// 错误信息，nil 时为空字符串
+func errString(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	return err.Error()
+}
+
+func TestCnRuleMessages(t *testing.T) {
+	tests := []struct {
+		rule string
+		want string
+	}{
+		{"cn_IdCard", "The IdCard must be a valid ID card number."},
+		{"cn_Mobile", "The Mobile must be a valid mobile number."},
+		{"cn_Tel", "The Tel must be a valid telephone number."},
+	}
+
+	for _, tt := range tests {
+		field := strings.TrimPrefix(tt.rule, "cn_")
+		err := New().AddRule(field, "string", tt.rule, "x").Validate()
+		if got := errString(err); got != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.rule, got, tt.want)
+		}
+	}
+}
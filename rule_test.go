@@ -0,0 +1,293 @@
+package validator
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+// 规则测试用例，value 的类型为字段类型
+type ruleCase struct {
+	rule  string
+	value interface{}
+	pass  bool
+}
+
+// 逐个验证规则测试用例
+func runRuleCases(t *testing.T, cases []ruleCase) {
+	t.Helper()
+
+	for _, tc := range cases {
+		fieldType := "string"
+		if tc.value != nil {
+			fieldType = reflect.TypeOf(tc.value).Kind().String()
+		}
+
+		err := New().AddRule("Field", fieldType, tc.rule, tc.value).Validate()
+		if _, ok := err.(ValidationErrors); err != nil && !ok {
+			t.Errorf("%s with %#v: unexpected error %v", tc.rule, tc.value, err)
+			continue
+		}
+
+		if (err == nil) != tc.pass {
+			t.Errorf("%s with %#v: got %v, want pass=%v", tc.rule, tc.value, err, tc.pass)
+		}
+	}
+}
+
+func TestSizeRules(t *testing.T) {
+	runRuleCases(t, []ruleCase{
+		{"size:3", "abc", true},
+		{"size:3", "中文字", true},
+		{"size:3", "ab", false},
+		{"size:3", 3, true},
+		{"size:3", 3.5, false},
+		{"size:2", []int{1, 2}, true},
+		{"size:2", map[string]int{"a": 1}, false},
+		{"len:2", "ab", true},
+		{"gt:3", 4, true},
+		{"gt:3", 3, false},
+		{"gt:3", "abcd", true},
+		{"gt:1.5", 1.6, true},
+		{"gte:3", 3, true},
+		{"gte:3", []string{"a", "b"}, false},
+		{"lt:3", 2, true},
+		{"lt:3", "abc", false},
+		{"lte:3", 3, true},
+		{"lte:3", map[int]int{1: 1, 2: 2, 3: 3, 4: 4}, false},
+		{"gt:x", 3, false},
+	})
+}
+
+func TestDigitsRules(t *testing.T) {
+	runRuleCases(t, []ruleCase{
+		{"digits:6", "012345", true},
+		{"digits:6", "12345", false},
+		{"digits:6", "12345a", false},
+		{"digits:3", 123, true},
+		{"digits:3", -12, false},
+		{"digits:3", uint(999), true},
+		{"digits:3", 1.23, false},
+		{"digits_between:2,4", "123", true},
+		{"digits_between:2,4", 12345, false},
+		{"digits_between:2", "12", false},
+	})
+}
+
+func TestInRules(t *testing.T) {
+	runRuleCases(t, []ruleCase{
+		{"in:a,b", "a", true},
+		{"in:a,b", "c", false},
+		{"in:1,2", 2, true},
+		{"in:1.5,2", 1.5, true},
+		{"not_in:a,b", "c", true},
+		{"not_in:a,b", "b", false},
+		{"not_in:1,2", 3, true},
+		{"not_in:1,2", 1, false},
+		{"not_in:1,2", []int{3}, false},
+	})
+}
+
+func TestTypeRules(t *testing.T) {
+	runRuleCases(t, []ruleCase{
+		{"integer", 1, true},
+		{"integer", uint8(1), true},
+		{"integer", "-12", true},
+		{"integer", "1.2", false},
+		{"integer", 1.0, false},
+		{"boolean", true, true},
+		{"boolean", 0, true},
+		{"boolean", 2, false},
+		{"boolean", "false", true},
+		{"boolean", "yes", false},
+		{"bool", "1", true},
+		{"accepted", "yes", true},
+		{"accepted", "ON", true},
+		{"accepted", 1, true},
+		{"accepted", false, false},
+		{"accepted", "no", false},
+	})
+}
+
+func TestCollectionRules(t *testing.T) {
+	runRuleCases(t, []ruleCase{
+		{"distinct", []string{"a", "b"}, true},
+		{"distinct", []string{"a", "a"}, false},
+		{"distinct", []int{1, 2, 1}, false},
+		{"distinct", map[string]int{"a": 1, "b": 1}, false},
+		{"distinct", map[string]int{"a": 1, "b": 2}, true},
+		{"distinct", "aa", false},
+	})
+}
+
+func TestSizeMessages(t *testing.T) {
+	tests := []struct {
+		rule      string
+		fieldType string
+		value     interface{}
+		message   string
+	}{
+		{"size:3", "string", "ab", "The Field must be 3 characters."},
+		{"size:3", "int", 2, "The Field must be 3."},
+		{"gt:3", "slice", []int{1}, "The Field must have more than 3 items."},
+		{"lte:2", "string", "abc", "The Field must be less than or equal 2 characters."},
+		{"digits:4", "string", "1", "The Field must be 4 digits."},
+		{"digits_between:2,4", "string", "1", "The Field must be between 2 and 4 digits."},
+		{"not_in:a,b", "string", "a", "The selected Field is invalid."},
+	}
+
+	for _, tt := range tests {
+		err := New().AddRule("Field", tt.fieldType, tt.rule, tt.value).Validate()
+		errs, ok := err.(ValidationErrors)
+		if !ok {
+			t.Errorf("%s: got %v", tt.rule, err)
+			continue
+		}
+
+		if errs[0].Message != tt.message {
+			t.Errorf("%s: got %q, want %q", tt.rule, errs[0].Message, tt.message)
+		}
+	}
+}
+
+type requiredKinds struct {
+	Id       int               `valid:"required"`
+	Price    float64           `valid:"required"`
+	Active   bool              `valid:"required"`
+	Tags     []string          `valid:"required"`
+	Meta     map[string]string `valid:"required"`
+	Count    *int              `valid:"required"`
+	Name     *string           `valid:"required"`
+	Any      interface{}       `valid:"required"`
+	At       time.Time         `valid:"required"`
+	Inner    struct{ X int }   `valid:"required"`
+	Optional *int              `valid:"nullable|min:1"`
+}
+
+func TestRequiredKinds(t *testing.T) {
+	zero, empty := 0, ""
+
+	tests := []struct {
+		name string
+		obj  requiredKinds
+		want []string
+	}{
+		{
+			"zero values",
+			requiredKinds{},
+			[]string{"Id", "Price", "Active", "Tags", "Meta", "Count", "Name", "Any", "At", "Inner"},
+		},
+		{
+			"pointer to zero is filled, pointer to empty string is not",
+			requiredKinds{Id: 1, Price: 0.1, Active: true, Tags: []string{""}, Meta: map[string]string{"": ""}, Count: &zero, Name: &empty, Any: 0, At: time.Now(), Inner: struct{ X int }{1}},
+			[]string{"Name"},
+		},
+	}
+
+	for _, tt := range tests {
+		var want []string
+		for _, name := range tt.want {
+			want = append(want, "requiredKinds."+name+".required")
+		}
+
+		if got := errorKeys(New().Struct(tt.obj).Validate()); !equalKeys(got, want) {
+			t.Errorf("%s: got %v, want %v", tt.name, got, want)
+		}
+	}
+
+	// nullable 的 nil 指针验证通过，不为 nil 时执行其他规则
+	obj := requiredKinds{Id: 1, Price: 1, Active: true, Tags: []string{"a"}, Meta: map[string]string{"a": "b"}, Count: &zero, Name: new(string), Any: 1, At: time.Now(), Inner: struct{ X int }{1}, Optional: &zero}
+	*obj.Name = "a"
+	if got := errorKeys(New().Struct(obj).Validate()); !equalKeys(got, []string{"requiredKinds.Optional.min"}) {
+		t.Errorf("nullable: got %v", got)
+	}
+}
+
+func TestPresenceRulesOnMapData(t *testing.T) {
+	// key 不存在、值为 nil、值为零值时各规则的结果
+	tests := []struct {
+		rule string
+		want [3]bool
+	}{
+		{"required", [3]bool{false, false, true}},
+		{"nullable", [3]bool{false, true, true}},
+		{"filled", [3]bool{true, false, true}},
+		{"present", [3]bool{false, true, true}},
+		{"sometimes|min:1", [3]bool{true, true, false}},
+		{"min:1", [3]bool{false, false, false}},
+	}
+
+	data := []map[string]interface{}{
+		{},
+		{"field": nil},
+		{"field": 0},
+	}
+
+	for _, tt := range tests {
+		for i, d := range data {
+			err := New().AddMapRule(map[string][]string{"field": {"int", tt.rule}}, d).Validate()
+			if pass := err == nil; pass != tt.want[i] {
+				t.Errorf("%s with %v: got %v, want pass=%v", tt.rule, d, err, tt.want[i])
+			}
+		}
+	}
+}
+
+func TestFilledRule(t *testing.T) {
+	runRuleCases(t, []ruleCase{
+		{"filled", "a", true},
+		{"filled", "", false},
+		{"filled", []int{}, false},
+		{"filled", 0, false},
+	})
+}
+
+func TestStringLengthRules(t *testing.T) {
+	runRuleCases(t, []ruleCase{
+		// 中文名字按字符个数计算
+		{"range:2,6", "张三丰", true},
+		{"max:3", "张三丰", true},
+		{"min:4", "张三丰", false},
+		{"size:2", "é!", true},
+		{"bytes:9", "张三丰", true},
+		{"bytes:8", "张三丰", false},
+		{"bytes:0,30", "abc", true},
+		{"bytes:4,30", "abc", false},
+		{"width:6", "张三丰", true},
+		{"width:5", "张三丰", false},
+		{"width:2,4", "ab", true},
+		{"width:2,4", "ａｂｃ", false},
+		{"width:1", "é", true},
+		{"bytes:x", "a", false},
+		{"bytes:1,2,3", "a", false},
+		{"bytes:3", 123, false},
+	})
+}
+
+func TestStringLengthMessages(t *testing.T) {
+	tests := []struct {
+		rule    string
+		locale  string
+		message string
+	}{
+		{"bytes:2", "en", "The Name may not be greater than 2 bytes."},
+		{"bytes:1,2", "en", "The Name must be between 1 and 2 bytes."},
+		{"width:2", "en", "The Name may not be wider than 2 columns."},
+		{"width:1,2", "en", "The Name must be between 1 and 2 columns wide."},
+		{"bytes:2", "zh-CN", "Name 不能多于 2 个字节"},
+		{"width:2", "zh-CN", "Name 的显示宽度不能大于 2"},
+	}
+
+	for _, tt := range tests {
+		err := New().AddRule("Name", "string", tt.rule, "张三").Validate(tt.locale)
+		errs, ok := err.(ValidationErrors)
+		if !ok {
+			t.Errorf("%s: got %v", tt.rule, err)
+			continue
+		}
+
+		if errs[0].Message != tt.message {
+			t.Errorf("%s %s: got %q, want %q", tt.rule, tt.locale, errs[0].Message, tt.message)
+		}
+	}
+}
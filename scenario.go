@@ -0,0 +1,93 @@
+package validator
+
+import (
+	"errors"
+	"strings"
+)
+
+// Scenario 设置验证场景，如 Struct(u).Scenario("update").Validate()
+// 规则可以通过 @ 指定生效的场景，如 required@create，多个场景以参数分隔符分隔，如 required@create,update
+// 未指定场景的规则在所有场景下生效；指定了场景的规则只在设置了对应场景时生效
+func (v *Validator) Scenario(name string) *Validator {
+	v.scenario = strings.TrimSpace(name)
+
+	return v
+}
+
+// 拆分规则名称中的场景，返回规则名称及场景列表
+func splitScenes(name string, syntax tagSyntax) (string, []string, error) {
+	pos := strings.Index(name, STR_SCENE)
+	if pos == -1 {
+		return name, nil, nil
+	}
+
+	ruleStr := strings.TrimSpace(name[:pos])
+	scenes := strings.FieldsFunc(name[pos+1:], func(r rune) bool {
+		return r == rune(STR_SCENE[0]) || r == rune(syntax.listSep)
+	})
+
+	for i, scene := range scenes {
+		scenes[i] = strings.TrimSpace(scene)
+		if scenes[i] == "" {
+			scenes = nil
+			break
+		}
+	}
+
+	if len(scenes) == 0 {
+		return "", nil, errors.New("rule error: Empty scenario of " + ruleStr + ".")
+	}
+
+	return ruleStr, scenes, nil
+}
+
+// 检查场景规则，dive、keys、endkeys 只用于划分元素规则，不能指定场景
+func checkSceneRules(items []*ruleItem) error {
+	for _, item := range items {
+		if len(item.scenes) == 0 {
+			continue
+		}
+
+		switch item.name {
+		case STR_DIVE, STR_KEYS, STR_ENDKEYS:
+			return errors.New("rule error: " + item.name + " does not support scenarios.")
+		}
+	}
+
+	return nil
+}
+
+// 规则是否在当前场景下生效
+func (v *Validator) inScene(item *ruleItem) bool {
+	if len(item.scenes) == 0 {
+		return true
+	}
+
+	for _, scene := range item.scenes {
+		if scene == v.scenario {
+			return v.scenario != ""
+		}
+	}
+
+	return false
+}
+
+// 当前场景下生效的规则，所有规则都生效时返回原列表
+func (v *Validator) sceneRules(rules []*ruleItem) []*ruleItem {
+	for i, item := range rules {
+		if v.inScene(item) {
+			continue
+		}
+
+		active := append(make([]*ruleItem, 0, len(rules)), rules[:i]...)
+		for _, item := range rules[i+1:] {
+			if v.inScene(item) {
+				active = append(active, item)
+			}
+		}
+
+		return active
+	}
+
+	return rules
+}
@@ -0,0 +1,100 @@
+package validator
+
+import (
+	"testing"
+)
+
+type sceneUser struct {
+	Id    int      `valid:"required@update|min:1"`
+	Name  string   `valid:"required@create|range@create,update:2,4"`
+	Email string   `valid:"required@create@update|email"`
+	Tags  []string `valid:"dive|in@create:a,b"`
+}
+
+func TestScenario(t *testing.T) {
+	tests := []struct {
+		scenario string
+		user     sceneUser
+		want     []string
+	}{
+		{"", sceneUser{}, []string{"sceneUser.Id.min", "sceneUser.Email.email"}},
+		{"create", sceneUser{Tags: []string{"c"}}, []string{"sceneUser.Id.min", "sceneUser.Name.required", "sceneUser.Name.range", "sceneUser.Email.required", "sceneUser.Email.email", "sceneUser.Tags[0].in"}},
+		{"create", sceneUser{Id: 1, Name: "abc", Email: "a@b.cn", Tags: []string{"a"}}, nil},
+		{" update ", sceneUser{Name: "a", Tags: []string{"c"}}, []string{"sceneUser.Id.required", "sceneUser.Id.min", "sceneUser.Name.range", "sceneUser.Email.required", "sceneUser.Email.email"}},
+		{"update", sceneUser{Id: 1, Name: "abc", Email: "a@b.cn"}, nil},
+		{"delete", sceneUser{Name: "a"}, []string{"sceneUser.Id.min", "sceneUser.Email.email"}},
+	}
+
+	for _, tt := range tests {
+		got := errorKeys(New().Struct(tt.user).Scenario(tt.scenario).Validate())
+		if !equalKeys(got, tt.want) {
+			t.Errorf("%q: got %v, want %v", tt.scenario, got, tt.want)
+		}
+	}
+}
+
+func TestScenarioRules(t *testing.T) {
+	tests := []struct {
+		rule     string
+		scenario string
+		value    interface{}
+		pass     bool
+	}{
+		{"required@create", "create", "", false},
+		{"required@create", "update", "", true},
+		{"required@create|min:2", "update", "a", false},
+		{"bail@create|min:2|max:0", "create", "a", false},
+		{"sometimes@update|required", "update", nil, true},
+		{"sometimes@update|required", "create", nil, false},
+		{"sometimes@update|required", "update", "", false},
+	}
+
+	for _, tt := range tests {
+		data := map[string]interface{}{}
+		if tt.value != nil {
+			data["field"] = tt.value
+		}
+
+		err := New().AddMapRule(map[string][]string{"field": {"string", tt.rule}}, data).Scenario(tt.scenario).Validate()
+		if _, ok := err.(ValidationErrors); err != nil && !ok {
+			t.Errorf("%s: unexpected error %v", tt.rule, err)
+			continue
+		}
+
+		if (err == nil) != tt.pass {
+			t.Errorf("%s in %q: got %v, want pass=%v", tt.rule, tt.scenario, err, tt.pass)
+		}
+	}
+}
+
+func TestScenarioErrors(t *testing.T) {
+	tests := []struct {
+		rule    string
+		wantErr string
+	}{
+		{"required@", "rule error: Empty scenario of required."},
+		{"required@ ,create", "rule error: Empty scenario of required."},
+		{"dive@create|required", "rule error: dive does not support scenarios."},
+		{"dive|keys@create|min:1|endkeys", "rule error: keys does not support scenarios."},
+	}
+
+	for _, tt := range tests {
+		err := New().AddRule("Field", "string", tt.rule, "x").Validate()
+		if got := errString(err); got != tt.wantErr {
+			t.Errorf("%s: got %q, want %q", tt.rule, got, tt.wantErr)
+		}
+	}
+}
+
+func TestScenarioClearError(t *testing.T) {
+	v := New()
+	if err := v.Struct(sceneUser{Id: 1, Email: "a@b.cn"}).Scenario("create").Validate(); err == nil {
+		t.Fatal("create: expected errors")
+	}
+
+	// ClearError 之后不再使用之前的场景
+	v.ClearError()
+	if err := v.Struct(sceneUser{Id: 1, Email: "a@b.cn"}).Validate(); err != nil {
+		t.Errorf("after ClearError: got %v", err)
+	}
+}
@@ -0,0 +1,520 @@
+package validator
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// 内置验证方法的签名，与 Rules 中验证方法保持一致
+type ruleFunc func(r *Rules, ruleVal, fieldType string, fieldVal reflect.Value) bool
+
+// 解析后的单条验证规则
+type ruleItem struct {
+	name  string   // 规则名称，内置规则为标准名称，其他规则为小写名称
+	param string   // 规则参数
+	fn    ruleFunc // 对应的内置验证方法，nil 表示需要查找自定义验证方法
+
+	fieldFn RuleFunc // 对应的内置字段关联验证方法
+	scenes  []string // 规则生效的验证场景，为空时在所有场景下生效
+
+	keys []*ruleItem // dive规则中map key的验证规则
+	each []*ruleItem // dive规则中元素的验证规则
+}
+
+// 结构体字段的验证信息
+type fieldSchema struct {
+	index     int         // 字段在结构体中的下标
+	name      string      // 字段名称
+	fieldType string      // 字段数据类型
+	rules     []*ruleItem // 字段验证规则
+	nested    bool        // 字段是否为需要递归验证的结构体或结构体指针
+	embedded  bool        // 字段是否为匿名嵌入字段，嵌入字段的子字段直接展开到上一级
+	custom    bool        // 字段是否包含需要在验证器中查找的自定义规则
+
+	structField reflect.StructField // 字段定义，用于生成字段名称
+
+	fieldMeta
+}
+
+// 字段的显示名称及自定义错误信息
+type fieldMeta struct {
+	label    string            // 错误信息中使用的字段显示名称，来自 label tag
+	messages map[string]string // 自定义错误信息，key 为规则名称，default 为字段所有规则的错误信息
+}
+
+// 数组、切片、map 元素的显示名称及自定义错误信息，显示名称加上元素的下标，如 标签[0]
+func (meta fieldMeta) elem(suffix string) fieldMeta {
+	if meta.label != "" {
+		meta.label += suffix
+	}
+
+	return meta
+}
+
+// Schema 编译后的结构体验证规则，可以通过 Validator.StructWithSchema 直接使用
+// Schema 创建后不可修改，可以在多个 goroutine 中共享使用
+type Schema struct {
+	typ    reflect.Type
+	syntax tagSyntax
+	fields []*fieldSchema
+}
+
+// Schema 缓存的 key，同一结构体类型在不同的 tag 语法下分别编译
+type schemaKey struct {
+	typ    reflect.Type
+	syntax tagSyntax
+}
+
+// 是否定义验证规则缓存的 key
+type validStructKey struct {
+	typ reflect.Type
+	tag string
+}
+
+var (
+	// 按结构体类型及 tag 语法缓存 Schema，schemaKey => *Schema
+	schemaCache sync.Map
+
+	// 按结构体类型及 tag 名称缓存是否定义了验证规则，validStructKey => bool
+	validStructCache sync.Map
+)
+
+// Compile 编译结构体的验证规则，obj 为结构体或结构体指针
+// 使用 WithTagName、WithSeparators 的验证器，需要传入相同的选项才能使用预编译的规则
+// 规则须为内置规则或通过 RegisterRule 全局注册的规则，只在验证器上注册的规则使用 Validator.Compile 检查
+func Compile(obj interface{}, opts ...Option) (*Schema, error) {
+	return SchemaFor(reflect.TypeOf(obj), opts...)
+}
+
+// SchemaFor 获取指定结构体类型的验证规则，同一类型只解析一次
+// objT 可以是结构体或结构体指针类型，嵌套的结构体及 dive 元素的结构体类型会一并编译
+func SchemaFor(objT reflect.Type, opts ...Option) (*Schema, error) {
+	v := New(opts...)
+	if v.err != nil {
+		return nil, v.err
+	}
+
+	return v.compile(objT)
+}
+
+// Compile 使用当前验证器的 tag 语法编译结构体的验证规则，规则可以是当前验证器注册的自定义规则
+func (v *Validator) Compile(obj interface{}) (*Schema, error) {
+	return v.compile(reflect.TypeOf(obj))
+}
+
+// 编译结构体的验证规则，并检查结构体及嵌套的结构体中的规则是否均已定义
+func (v *Validator) compile(objT reflect.Type) (*Schema, error) {
+	schema, err := schemaForSyntax(objT, v.syntax)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := v.checkSchemaRules(schema, schema.typ.Name(), make(map[reflect.Type]bool)); err != nil {
+		return nil, err
+	}
+
+	return schema, nil
+}
+
+// 检查 Schema 中的自定义规则是否已注册，prefix 为错误信息中的字段路径，seen 记录已检查的结构体类型
+func (v *Validator) checkSchemaRules(schema *Schema, prefix string, seen map[reflect.Type]bool) error {
+	seen[schema.typ] = true
+
+	for _, field := range schema.fields {
+		fieldKey := joinKey(prefix, field.name)
+		if field.custom {
+			if err := v.checkRuleNames(fieldKey, field.rules); err != nil {
+				return err
+			}
+		}
+
+		if field.embedded {
+			fieldKey = prefix
+		}
+
+		nestedT, ok := elemStructType(field.structField.Type)
+		if !ok || seen[nestedT] || !isValidStruct(nestedT, schema.syntax.tag) {
+			continue
+		}
+
+		nested, err := schemaForSyntax(nestedT, schema.syntax)
+		if err != nil {
+			return err
+		}
+
+		if err := v.checkSchemaRules(nested, fieldKey, seen); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// 获取结构体、结构体指针或其数组、切片、map 元素对应的结构体类型
+func elemStructType(t reflect.Type) (reflect.Type, bool) {
+	for {
+		switch t.Kind() {
+		case reflect.Ptr, reflect.Slice, reflect.Array, reflect.Map:
+			t = t.Elem()
+		case reflect.Struct:
+			return t, true
+		default:
+			return nil, false
+		}
+	}
+}
+
+// 获取指定 tag 语法下结构体类型的验证规则
+func schemaForSyntax(objT reflect.Type, syntax tagSyntax) (*Schema, error) {
+	for objT != nil && objT.Kind() == reflect.Ptr {
+		objT = objT.Elem()
+	}
+
+	return schemaFor(objT, syntax, make(map[reflect.Type]bool))
+}
+
+// 获取结构体类型的验证规则，compiling 记录正在编译的类型，用于处理自引用的结构体
+func schemaFor(objT reflect.Type, syntax tagSyntax, compiling map[reflect.Type]bool) (*Schema, error) {
+	if objT == nil || objT.Kind() != reflect.Struct {
+		return nil, errors.New("rule error: Schema requires a struct type.")
+	}
+
+	key := schemaKey{typ: objT, syntax: syntax}
+	if cached, ok := schemaCache.Load(key); ok {
+		return cached.(*Schema), nil
+	}
+
+	compiling[objT] = true
+	schema, err := compileSchema(objT, syntax, compiling)
+	delete(compiling, objT)
+
+	if err != nil {
+		return nil, err
+	}
+
+	cached, _ := schemaCache.LoadOrStore(key, schema)
+
+	return cached.(*Schema), nil
+}
+
+// Type 返回 Schema 对应的结构体类型
+func (s *Schema) Type() reflect.Type {
+	return s.typ
+}
+
+// 解析结构体中每个字段的 tag，没有 tag 的字段不验证
+// 结构体或结构体指针字段如果包含验证规则，则递归编译；嵌入字段没有 tag 时只展开其子字段
+func compileSchema(objT reflect.Type, syntax tagSyntax, compiling map[reflect.Type]bool) (*Schema, error) {
+	schema := &Schema{
+		typ:    objT,
+		syntax: syntax,
+		fields: make([]*fieldSchema, 0, objT.NumField()),
+	}
+
+	for i := 0; i < objT.NumField(); i++ {
+		field := objT.Field(i)
+		tag := strings.TrimSpace(field.Tag.Get(syntax.tag))
+
+		nestedT, nested := structType(field.Type)
+		nested = nested && isValidStruct(nestedT, syntax.tag)
+
+		if tag == "" && !nested {
+			continue
+		}
+
+		rules, err := parseRule(tag, syntax)
+		if err != nil {
+			return nil, err
+		}
+
+		messages, err := parseMessages(syntax, field.Tag.Get(STR_MSG))
+		if err != nil {
+			return nil, err
+		}
+
+		if nested && !compiling[nestedT] {
+			if _, err := schemaFor(nestedT, syntax, compiling); err != nil {
+				return nil, err
+			}
+		}
+
+		schema.fields = append(schema.fields, &fieldSchema{
+			index:     i,
+			name:      field.Name,
+			fieldType: field.Type.Kind().String(),
+			rules:     rules,
+			nested:    nested,
+			embedded:  field.Anonymous,
+			custom:    hasCustomRule(rules),
+			fieldMeta: fieldMeta{
+				label:    strings.TrimSpace(field.Tag.Get(STR_LABEL)),
+				messages: messages,
+			},
+			structField: field,
+		})
+	}
+
+	return schema, nil
+}
+
+// 获取结构体或结构体指针对应的结构体类型
+func structType(t reflect.Type) (reflect.Type, bool) {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	return t, t.Kind() == reflect.Struct
+}
+
+// 结构体中是否定义了验证规则，结果按类型缓存
+func isValidStruct(objT reflect.Type, tag string) bool {
+	key := validStructKey{typ: objT, tag: tag}
+	if cached, ok := validStructCache.Load(key); ok {
+		return cached.(bool)
+	}
+
+	valid := hasValidTag(objT, tag, make(map[reflect.Type]bool))
+	validStructCache.Store(key, valid)
+
+	return valid
+}
+
+// 结构体及其嵌套的结构体中是否定义了验证规则，没有验证规则的结构体（如 time.Time）作为普通字段处理
+func hasValidTag(objT reflect.Type, tag string, seen map[reflect.Type]bool) bool {
+	if seen[objT] {
+		return false
+	}
+	seen[objT] = true
+
+	for i := 0; i < objT.NumField(); i++ {
+		field := objT.Field(i)
+		if _, ok := field.Tag.Lookup(tag); ok {
+			return true
+		}
+
+		if nestedT, ok := structType(field.Type); ok && hasValidTag(nestedT, tag, seen) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// 解析规则，把字符串通过分隔符转换成规则列表，规则顺序与书写顺序一致
+func parseRule(rules string, syntax tagSyntax) ([]*ruleItem, error) {
+	rules = strings.TrimSpace(rules)
+	if rules == "" {
+		return nil, nil
+	}
+
+	tokens, err := tokenizeRule(rules, syntax)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]*ruleItem, 0, len(tokens))
+	for _, token := range tokens {
+		name, scenes, err := splitScenes(token.name, syntax)
+		if err != nil {
+			return nil, err
+		}
+
+		// 内置规则使用标准名称，其他规则名称统一转化为小写
+		item := &ruleItem{name: strings.ToLower(name), param: token.param, scenes: scenes}
+		if canonical, fn, fieldFn, ok := lookupBuiltinRule(name); ok {
+			item.name = canonical
+			item.fn = fn
+			item.fieldFn = fieldFn
+		}
+		item.param = syntax.normalizeParam(item.name, item.param)
+
+		items = append(items, item)
+	}
+
+	if err := checkRegexRules(items); err != nil {
+		return nil, err
+	}
+
+	if err := checkRuleParams(items); err != nil {
+		return nil, err
+	}
+
+	if err := checkSceneRules(items); err != nil {
+		return nil, err
+	}
+
+	return parseDive(items)
+}
+
+// 规则字符串中的单条规则
+type ruleToken struct {
+	name  string
+	param string
+}
+
+// 按 | 拆分规则字符串，规则名称与参数以第一个 : 分隔，没有参数时参数为 STR_NULL
+// 参数以单引号包裹时，其中的 | 和 : 均为普通字符，两个连续的单引号表示一个单引号，如 regex:'^(a|b)$'
+// 参数未使用单引号时，可以使用 \| 表示普通字符 |；分隔符可以通过 WithSeparators 修改
+func tokenizeRule(rules string, syntax tagSyntax) ([]ruleToken, error) {
+	var tokens []ruleToken
+	var buf strings.Builder
+
+	name, hasParam := "", false
+	flush := func() {
+		token := ruleToken{name: strings.TrimSpace(buf.String()), param: STR_NULL}
+		if hasParam {
+			token = ruleToken{name: name, param: strings.TrimSpace(buf.String())}
+		}
+
+		// 忽略空规则，如 "required||email"
+		if token.name != "" {
+			tokens = append(tokens, token)
+		}
+
+		buf.Reset()
+		name, hasParam = "", false
+	}
+
+	for i := 0; i < len(rules); i++ {
+		c := rules[i]
+		switch {
+		case c == '\\' && i+1 < len(rules) && rules[i+1] == syntax.ruleSep:
+			buf.WriteByte(syntax.ruleSep)
+			i++
+		case c == syntax.ruleSep:
+			flush()
+		case c == syntax.paramSep && !hasParam:
+			name, hasParam = strings.TrimSpace(buf.String()), true
+			buf.Reset()
+
+			j := i + 1
+			for j < len(rules) && rules[j] == ' ' {
+				j++
+			}
+
+			if j == len(rules) || rules[j] != '\'' {
+				continue
+			}
+
+			param, end, err := readQuoted(rules, j, name)
+			if err != nil {
+				return nil, err
+			}
+
+			for end < len(rules) && rules[end] == ' ' {
+				end++
+			}
+
+			if end < len(rules) && rules[end] != syntax.ruleSep {
+				return nil, errors.New("rule error: Unexpected character after quoted parameter of " + name + ".")
+			}
+
+			if name != "" {
+				tokens = append(tokens, ruleToken{name: name, param: param})
+			}
+
+			name, hasParam = "", false
+			i = end
+		default:
+			buf.WriteByte(c)
+		}
+	}
+
+	flush()
+
+	return tokens, nil
+}
+
+// 读取以单引号包裹的参数，start 为开始引号的位置，返回参数及结束引号之后的位置
+func readQuoted(rules string, start int, name string) (string, int, error) {
+	var buf strings.Builder
+	for i := start + 1; i < len(rules); i++ {
+		if rules[i] != '\'' {
+			buf.WriteByte(rules[i])
+			continue
+		}
+
+		// 两个连续的单引号表示一个单引号
+		if i+1 < len(rules) && rules[i+1] == '\'' {
+			buf.WriteByte('\'')
+			i++
+			continue
+		}
+
+		return buf.String(), i + 1, nil
+	}
+
+	return "", 0, errors.New("rule error: Unterminated quoted parameter of " + name + ".")
+}
+
+// 处理dive规则，dive之后的规则作为元素规则归入dive规则中
+// map的key规则写在 keys 与 endkeys 之间，如：dive|keys|alphaDash|endkeys|min:0
+func parseDive(items []*ruleItem) ([]*ruleItem, error) {
+	for i, item := range items {
+		if item.name != STR_DIVE {
+			continue
+		}
+
+		rest := items[i+1:]
+		if len(rest) > 0 && rest[0].name == STR_KEYS {
+			end := -1
+			for j, keyItem := range rest {
+				if keyItem.name == STR_ENDKEYS {
+					end = j
+					break
+				}
+			}
+
+			if end == -1 {
+				return nil, errors.New("rule error: Missing endkeys after keys.")
+			}
+
+			keys, err := parseDive(rest[1:end])
+			if err != nil {
+				return nil, err
+			}
+
+			item.keys = keys
+			rest = rest[end+1:]
+		}
+
+		each, err := parseDive(rest)
+		if err != nil {
+			return nil, err
+		}
+
+		item.each = each
+
+		return items[:i+1], nil
+	}
+
+	return items, nil
+}
+
+// 规则列表（包括 dive 的元素规则）中是否包含非内置的规则
+func hasCustomRule(rules []*ruleItem) bool {
+	for _, item := range rules {
+		if item.fn == nil && item.fieldFn == nil && item.name != STR_DIVE {
+			return true
+		}
+
+		if hasCustomRule(item.keys) || hasCustomRule(item.each) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// 规则列表中是否包含指定名称的规则
+func hasRule(rules []*ruleItem, name string) bool {
+	for _, item := range rules {
+		if item.name == name {
+			return true
+		}
+	}
+
+	return false
+}
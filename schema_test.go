@@ -0,0 +1,128 @@
+package validator
+
+import (
+	"reflect"
+	"testing"
+)
+
+type schemaUser struct {
+	Name  string `valid:"required|range:2,8"`
+	Email string `valid:"required|email"`
+	Note  string
+}
+
+type schemaOther struct {
+	Name string `valid:"required"`
+}
+
+func TestCompileCachesSchema(t *testing.T) {
+	a, err := Compile(schemaUser{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := SchemaFor(reflect.TypeOf(&schemaUser{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if a != b {
+		t.Error("Compile and SchemaFor should return the cached schema")
+	}
+
+	if a.Type() != reflect.TypeOf(schemaUser{}) {
+		t.Errorf("Type() = %v", a.Type())
+	}
+
+	// 未定义规则的字段不编译
+	if len(a.fields) != 2 {
+		t.Errorf("got %d fields, want 2", len(a.fields))
+	}
+
+	// 不同的 tag 语法分别编译
+	c, err := Compile(schemaOther{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	d, err := Compile(schemaOther{}, WithSeparators(";", "=", ","))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if c == d {
+		t.Error("schemas with different syntax should not be shared")
+	}
+}
+
+func TestCompileErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		obj  interface{}
+	}{
+		{"nil", nil},
+		{"int", 1},
+		{"map", map[string]int{}},
+		{"bad rule", struct {
+			Name string `valid:"regex:'abc"`
+		}{}},
+	}
+
+	for _, tt := range tests {
+		if _, err := Compile(tt.obj); err == nil {
+			t.Errorf("%s: expected an error", tt.name)
+		}
+	}
+}
+
+func TestStructWithSchema(t *testing.T) {
+	schema, err := Compile(schemaUser{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		schema  *Schema
+		obj     interface{}
+		want    []string
+		wantErr bool
+	}{
+		{"valid", schema, schemaUser{Name: "tom", Email: "tom@example.com"}, nil, false},
+		{"pointer", schema, &schemaUser{Name: "tom", Email: "tom"}, []string{"schemaUser.Email.email"}, false},
+		{"empty", schema, schemaUser{}, []string{"schemaUser.Name.required", "schemaUser.Name.range", "schemaUser.Email.required", "schemaUser.Email.email"}, false},
+		{"nil schema", nil, schemaUser{}, nil, true},
+		{"type mismatch", schema, schemaOther{}, nil, true},
+		{"nil pointer", schema, (*schemaUser)(nil), nil, true},
+	}
+
+	for _, tt := range tests {
+		err := New().StructWithSchema(tt.schema, tt.obj).Validate()
+		if tt.wantErr {
+			if _, ok := err.(ValidationErrors); err == nil || ok {
+				t.Errorf("%s: expected a rule or data error, got %v", tt.name, err)
+			}
+			continue
+		}
+
+		if got := errorKeys(err); !equalKeys(got, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestStructWithSchemaUsesSchemaSyntax(t *testing.T) {
+	type tagged struct {
+		Name string `valid:"email" check:"required;range=2,8"`
+	}
+
+	schema, err := Compile(tagged{}, WithTagName("check"), WithSeparators(";", "=", ","))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = New().StructWithSchema(schema, tagged{Name: "a"}).Validate()
+	if got := errorKeys(err); !equalKeys(got, []string{"tagged.Name.range"}) {
+		t.Errorf("got %v", got)
+	}
+}
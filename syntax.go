@@ -0,0 +1,64 @@
+package validator
+
+import (
+	"errors"
+	"strings"
+)
+
+// 验证规则 tag 的名称及分隔符
+type tagSyntax struct {
+	tag      string // 验证规则的 tag 名称
+	ruleSep  byte   // 规则之间的分隔符
+	paramSep byte   // 规则名称与参数之间的分隔符
+	listSep  byte   // 参数之间的分隔符
+}
+
+// 默认语法，如 valid:"required|range:6,20"
+var defaultSyntax = tagSyntax{tag: STR_VALID, ruleSep: '|', paramSep: ':', listSep: ','}
+
+// 参数为单个值的规则，参数中的列表分隔符不做转换
+var singleParamRules = map[string]bool{
+	"regex":       true,
+	"not_regex":   true,
+	"date_format": true,
+}
+
+// WithTagName 设置验证规则的 tag 名称，默认为 valid，如 WithTagName("validate")
+// 用于与其他同样使用 valid tag 的库共用结构体
+func WithTagName(name string) Option {
+	return func(v *Validator) {
+		name = strings.TrimSpace(name)
+		if name == "" || strings.ContainsAny(name, " :\"") {
+			v.setError(errors.New("rule error: Invalid tag name " + name + "."))
+			return
+		}
+
+		v.syntax.tag = name
+	}
+}
+
+// WithSeparators 设置规则之间、规则名称与参数之间、参数之间的分隔符，默认为 |、:、,
+// 分隔符必须是互不相同的单个字符，且不能为空格、单引号或反斜杠，如 WithSeparators(";", "=", ",")
+// 自定义参数分隔符时，参数中的分隔符统一转换为逗号，regex、not_regex、date_format 的参数除外
+func WithSeparators(rule, param, list string) Option {
+	return func(v *Validator) {
+		seps := []string{rule, param, list}
+		for i, sep := range seps {
+			if len(sep) != 1 || strings.ContainsAny(sep, " '\\") || strings.Contains(strings.Join(seps[:i], ""), sep) {
+				v.setError(errors.New("rule error: Invalid separators " + strings.Join(seps, " ") + "."))
+				return
+			}
+		}
+
+		v.syntax.ruleSep, v.syntax.paramSep, v.syntax.listSep = rule[0], param[0], list[0]
+	}
+}
+
+// 把参数中的列表分隔符转换为逗号
+func (s tagSyntax) normalizeParam(name, param string) string {
+	if s.listSep == ',' || param == STR_NULL || singleParamRules[name] {
+		return param
+	}
+
+	return strings.Replace(param, string(s.listSep), ",", -1)
+}
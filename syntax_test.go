@@ -0,0 +1,129 @@
+package validator
+
+import (
+	"testing"
+)
+
+type syntaxInner struct {
+	Code string `validate:"required" valid:"email"`
+}
+
+type syntaxUser struct {
+	Name  string      `validate:"required;range=2/4" valid:"email" msg:"range=长度为 :min 到 :max"`
+	Level string      `validate:"in=a/b/c"`
+	Code  string      `validate:"regex='^a/b$'"`
+	Day   string      `validate:"date_format=%Y/%m/%d"`
+	Inner syntaxInner `validate:"required"`
+}
+
+func TestTagSyntax(t *testing.T) {
+	tests := []struct {
+		name string
+		user syntaxUser
+		want []string
+	}{
+		{"valid", syntaxUser{Name: "abc", Level: "b", Code: "a/b", Day: "2024/02/29", Inner: syntaxInner{Code: "x"}}, nil},
+		{
+			"invalid",
+			syntaxUser{Name: "a", Level: "d", Code: "a", Day: "2024-02-29"},
+			[]string{"syntaxUser.Name.range", "syntaxUser.Level.in", "syntaxUser.Code.regex", "syntaxUser.Day.date_format", "syntaxUser.Inner.required", "syntaxUser.Inner.Code.required"},
+		},
+	}
+
+	for _, tt := range tests {
+		v := New(WithTagName("validate"), WithSeparators(";", "=", "/"))
+		if got := errorKeys(v.Struct(tt.user).Validate()); !equalKeys(got, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	// 自定义语法的错误信息使用相同的分隔符
+	err := New(WithTagName("validate"), WithSeparators(";", "=", "/")).Struct(syntaxUser{Name: "a", Level: "a", Code: "a/b", Day: "2024/02/29", Inner: syntaxInner{Code: "x"}}).Validate()
+	if got := errorMessages(err); !equalKeys(got, []string{"长度为 2 到 4"}) {
+		t.Errorf("msg tag: got %q", got)
+	}
+}
+
+func TestTagSyntaxAddRule(t *testing.T) {
+	tests := []struct {
+		rule  string
+		value string
+		pass  bool
+	}{
+		{"required;in=a/b", "b", true},
+		{"required;in=a/b", "c", false},
+		{"range=1/2", "abc", false},
+		{"regex='^a;b$'", "a;b", true},
+		{"in='a;b/c'", "a;b", true},
+		{"in='a;b/c'", "a;b/c", false},
+	}
+
+	for _, tt := range tests {
+		err := New(WithSeparators(";", "=", "/")).AddRule("Field", "string", tt.rule, tt.value).Validate()
+		if _, ok := err.(ValidationErrors); err != nil && !ok {
+			t.Errorf("%s: unexpected error %v", tt.rule, err)
+			continue
+		}
+
+		if (err == nil) != tt.pass {
+			t.Errorf("%s with %q: got %v, want pass=%v", tt.rule, tt.value, err, tt.pass)
+		}
+	}
+}
+
+func TestTagSyntaxOptions(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    []Option
+		wantErr string
+	}{
+		{"tag name", []Option{WithTagName(" validate ")}, ""},
+		{"empty tag name", []Option{WithTagName(" ")}, "rule error: Invalid tag name ."},
+		{"tag name with space", []Option{WithTagName("a b")}, "rule error: Invalid tag name a b."},
+		{"tag name with colon", []Option{WithTagName("a:b")}, "rule error: Invalid tag name a:b."},
+		{"separators", []Option{WithSeparators(";", "=", "/")}, ""},
+		{"empty separator", []Option{WithSeparators("", "=", "/")}, "rule error: Invalid separators  = /."},
+		{"long separator", []Option{WithSeparators("||", ":", ",")}, "rule error: Invalid separators || : ,."},
+		{"duplicate separators", []Option{WithSeparators(";", ";", ",")}, "rule error: Invalid separators ; ; ,."},
+		{"quote separator", []Option{WithSeparators("|", "'", ",")}, "rule error: Invalid separators | ' ,."},
+		{"backslash separator", []Option{WithSeparators("|", ":", "\\")}, "rule error: Invalid separators | : \\."},
+		{"space separator", []Option{WithSeparators(" ", ":", ",")}, "rule error: Invalid separators   : ,."},
+	}
+
+	for _, tt := range tests {
+		err := New(tt.opts...).Struct(syntaxInner{Code: "x"}).Validate()
+		if _, ok := err.(ValidationErrors); ok {
+			err = nil
+		}
+
+		if got := errString(err); got != tt.wantErr {
+			t.Errorf("%s: got %q, want %q", tt.name, got, tt.wantErr)
+		}
+
+		if _, err := Compile(syntaxInner{}, tt.opts...); errString(err) != tt.wantErr {
+			t.Errorf("%s: Compile got %q, want %q", tt.name, errString(err), tt.wantErr)
+		}
+	}
+}
+
+func TestTagSyntaxSchemaCache(t *testing.T) {
+	// 不同语法的 Schema 分别缓存
+	inner := syntaxInner{Code: "x"}
+
+	if got := errorKeys(New().Struct(inner).Validate()); !equalKeys(got, []string{"syntaxInner.Code.email"}) {
+		t.Errorf("valid tag: got %v", got)
+	}
+
+	if err := New(WithTagName("validate")).Struct(inner).Validate(); err != nil {
+		t.Errorf("validate tag: got %v", err)
+	}
+
+	schema, err := Compile(inner, WithTagName("validate"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := errorKeys(New().StructWithSchema(schema, syntaxInner{}).Validate()); !equalKeys(got, []string{"syntaxInner.Code.required"}) {
+		t.Errorf("StructWithSchema: got %v", got)
+	}
+}
@@ -1,7 +1,11 @@
 package validator
 
 import (
+	"reflect"
 	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
 )
 
 // Matches 正则表达
@@ -47,37 +51,41 @@ func getType(strType string) string {
 	return retType
 }
 
+// getTypeMapping 使用的类型格式，只编译一次
+var (
+	rxTypeSlice = regexp.MustCompile("\\[\\d{0,}\\].*$")       // 切片或者数组 格式：[\d]type
+	rxTypeMap   = regexp.MustCompile("^map\\[[a-zA-Z]+\\].*$") // map 格式：map[type]开头的类型
+	rxTypeChan  = regexp.MustCompile("^chan.*$")               // Channel 格式：chan开头的类型
+)
+
 // 根据类型进行映射
 // int, float类型统一返回 numeric
 func getTypeMapping(strType string) string {
 	retType := ""
 
-	// 匹配切片或者数组 格式：[\d]type
-	regSlice := "\\[\\d{0,}\\].*$"
-	rxSlice := regexp.MustCompile(regSlice)
-	if rxSlice.MatchString(strType) {
+	if rxTypeSlice.MatchString(strType) {
 		return "array"
 	}
 
-	// 匹配map 格式：map[type]开头的类型
-	regMap := "^map\\[[a-zA-Z]+\\].*$"
-	rxMap := regexp.MustCompile(regMap)
-	if rxMap.MatchString(strType) {
+	if rxTypeMap.MatchString(strType) {
 		return "map"
 	}
 
-	// 匹配Channel 格式：chan开头的类型
-	regChan := "^chan.*$"
-	rxChan := regexp.MustCompile(regChan)
-	if rxChan.MatchString(strType) {
+	if rxTypeChan.MatchString(strType) {
 		return "chan"
 	}
 
 	switch strType {
+	case "slice", "array": // 结构体字段使用 Kind 名称
+		retType = "array"
+	case "map", "chan":
+		retType = strType
 	case "int", "uint", "byte", "uintptr", "rune", "uint8", "uint16", "uint32", "uint64", "int8", "int16", "int32", "int64":
 		retType = "int"
-	case "float", "float32", "float64", "complex64", "complex128":
+	case "float", "float32", "float64":
 		retType = "float"
+	case "complex64", "complex128":
+		retType = "complex"
 	case "string":
 		retType = "string"
 	default:
@@ -86,3 +94,216 @@ func getTypeMapping(strType string) string {
 
 	return retType
 }
+
+// 获取反射值对应的数据，无效或不可导出的值返回 nil
+func valueInterface(val reflect.Value) interface{} {
+	if val.IsValid() && val.CanInterface() {
+		return val.Interface()
+	}
+
+	return nil
+}
+
+// 获取指针或接口指向的值，nil 时返回无效值
+func indirectValue(val reflect.Value) reflect.Value {
+	for val.IsValid() && (val.Kind() == reflect.Ptr || val.Kind() == reflect.Interface) {
+		if val.IsNil() {
+			return reflect.Value{}
+		}
+
+		val = val.Elem()
+	}
+
+	return val
+}
+
+// 比较两个数字的大小，a 小于、等于、大于 b 时分别返回 -1、0、1，任一值不是数字时返回 false
+// 有符号整数、无符号整数之间按整数精确比较，包含浮点数时按 float64 比较
+func compareNumber(a, b reflect.Value) (int, bool) {
+	if !isNumberKind(a.Kind()) || !isNumberKind(b.Kind()) {
+		return 0, false
+	}
+
+	switch {
+	case isIntKind(a.Kind()) && isIntKind(b.Kind()):
+		return compareInt(a.Int(), b.Int()), true
+	case isUintKind(a.Kind()) && isUintKind(b.Kind()):
+		return compareUint(a.Uint(), b.Uint()), true
+	case isIntKind(a.Kind()) && isUintKind(b.Kind()):
+		if a.Int() < 0 {
+			return -1, true
+		}
+
+		return compareUint(uint64(a.Int()), b.Uint()), true
+	case isUintKind(a.Kind()) && isIntKind(b.Kind()):
+		if b.Int() < 0 {
+			return 1, true
+		}
+
+		return compareUint(a.Uint(), uint64(b.Int())), true
+	}
+
+	return compareFloat(floatOf(a), floatOf(b)), true
+}
+
+// 数值转换为 float64
+func floatOf(val reflect.Value) float64 {
+	switch {
+	case isIntKind(val.Kind()):
+		return float64(val.Int())
+	case isUintKind(val.Kind()):
+		return float64(val.Uint())
+	}
+
+	return val.Float()
+}
+
+// 是否为有符号整数
+func isIntKind(kind reflect.Kind) bool {
+	return kind >= reflect.Int && kind <= reflect.Int64
+}
+
+// 是否为无符号整数
+func isUintKind(kind reflect.Kind) bool {
+	return kind >= reflect.Uint && kind <= reflect.Uintptr
+}
+
+// 是否为整数或浮点数
+func isNumberKind(kind reflect.Kind) bool {
+	return isIntKind(kind) || isUintKind(kind) || kind == reflect.Float32 || kind == reflect.Float64
+}
+
+func compareInt(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+
+	return 0
+}
+
+func compareUint(a, b uint64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+
+	return 0
+}
+
+func compareFloat(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+
+	return 0
+}
+
+// 比较数字与规则参数的大小，参数按整数或浮点数解析，整数之间精确比较
+func compareNumberParam(val reflect.Value, param string) (int, bool) {
+	param = strings.TrimSpace(param)
+
+	if i, err := strconv.ParseInt(param, 10, 64); err == nil {
+		return compareNumber(val, reflect.ValueOf(i))
+	}
+
+	if u, err := strconv.ParseUint(param, 10, 64); err == nil {
+		return compareNumber(val, reflect.ValueOf(u))
+	}
+
+	if f, err := strconv.ParseFloat(param, 64); err == nil {
+		return compareNumber(val, reflect.ValueOf(f))
+	}
+
+	return 0, false
+}
+
+// 值是否为 nil 指针或 nil 接口
+func isNilValue(val reflect.Value) bool {
+	kind := val.Kind()
+	return (kind == reflect.Ptr || kind == reflect.Interface) && val.IsNil()
+}
+
+// 值是否为空：不存在、nil、空字符串、长度为 0 的切片、map、chan 以及其他类型的零值
+// 非 nil 的指针、接口视为已填写，只有指向空字符串或空集合时为空，可以用指针字段或 map 数据区分未填写与零值
+func isEmptyValue(val reflect.Value) bool {
+	if !val.IsValid() {
+		return true
+	}
+
+	switch val.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		return isBlank(val)
+	case reflect.String, reflect.Slice, reflect.Map, reflect.Chan:
+		return val.Len() == 0
+	}
+
+	return val.IsZero()
+}
+
+// 数字转换为字符串，有符号、无符号整数及浮点数分别按各自的类型转换
+func numberString(val reflect.Value) (string, bool) {
+	switch kind := val.Kind(); {
+	case isIntKind(kind):
+		return strconv.FormatInt(val.Int(), 10), true
+	case isUintKind(kind):
+		return strconv.FormatUint(val.Uint(), 10), true
+	case kind == reflect.Float32:
+		return strconv.FormatFloat(val.Float(), 'f', -1, 32), true
+	case kind == reflect.Float64:
+		return strconv.FormatFloat(val.Float(), 'f', -1, 64), true
+	}
+
+	return "", false
+}
+
+// 宽字符的 Unicode 范围，包括中日韩文字、全角符号、谚文及常用 emoji
+var wideRanges = [][2]rune{
+	{0x1100, 0x115F},
+	{0x2E80, 0x303E},
+	{0x3041, 0x33FF},
+	{0x3400, 0x4DBF},
+	{0x4E00, 0x9FFF},
+	{0xA000, 0xA4CF},
+	{0xAC00, 0xD7A3},
+	{0xF900, 0xFAFF},
+	{0xFE30, 0xFE4F},
+	{0xFF00, 0xFF60},
+	{0xFFE0, 0xFFE6},
+	{0x1F300, 0x1F64F},
+	{0x1F900, 0x1F9FF},
+	{0x20000, 0x2FFFD},
+	{0x30000, 0x3FFFD},
+}
+
+// 字符串的显示宽度，宽字符计为 2，组合字符及格式字符计为 0，其他字符计为 1
+func stringWidth(str string) int {
+	width := 0
+	for _, r := range str {
+		width += runeWidth(r)
+	}
+
+	return width
+}
+
+// 单个字符的显示宽度
+func runeWidth(r rune) int {
+	if unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf) {
+		return 0
+	}
+
+	for _, wide := range wideRanges {
+		if r >= wide[0] && r <= wide[1] {
+			return 2
+		}
+	}
+
+	return 1
+}
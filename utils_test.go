@@ -0,0 +1,79 @@
+package validator
+
+import (
+	"testing"
+)
+
+func TestGetTypeMapping(t *testing.T) {
+	tests := []struct {
+		typ  string
+		want string
+	}{
+		{"int", "int"},
+		{"uint64", "int"},
+		{"uintptr", "int"},
+		{"float32", "float"},
+		{"complex128", "complex"},
+		{"string", "string"},
+		{"slice", "array"},
+		{"[]string", "array"},
+		{"[3]int", "array"},
+		{"map", "map"},
+		{"map[string]int", "map"},
+		{"chan", "chan"},
+		{"chan int", "chan"},
+		{"bool", "unknown"},
+	}
+
+	for _, tt := range tests {
+		if got := getTypeMapping(tt.typ); got != tt.want {
+			t.Errorf("%s: got %s, want %s", tt.typ, got, tt.want)
+		}
+	}
+}
+
+func TestNumericKinds(t *testing.T) {
+	runRuleCases(t, []ruleCase{
+		{"min:1", uint8(1), true},
+		{"min:2", uint16(1), false},
+		{"max:4294967295", uint32(4294967295), true},
+		{"range:1,10", uintptr(5), true},
+		{"max:9223372036854775807", uint64(18446744073709551615), false},
+		{"min:9223372036854775808", uint64(18446744073709551615), true},
+		{"in:18446744073709551615", uint64(18446744073709551615), true},
+		{"range:-5,5", int8(-5), true},
+		{"range:0.5,1.5", float32(1.25), true},
+		{"max:1.5", 1.6, false},
+		{"min:-1", int64(-2), false},
+		{"gt:1.5", uint(2), true},
+		{"size:3", uint8(3), true},
+		{"port", uint16(8080), true},
+	})
+}
+
+func TestComplexRejected(t *testing.T) {
+	tests := []struct {
+		rule  string
+		value interface{}
+	}{
+		{"min:1", complex64(1)},
+		{"range:1,2", complex128(1)},
+		{"dive|max:1", []complex128{1}},
+	}
+
+	for _, tt := range tests {
+		err := New().AddRule("Field", "complex128", tt.rule, tt.value).Validate()
+		if _, ok := err.(ValidationErrors); err == nil || ok {
+			t.Errorf("%s: expected a rule error, got %v", tt.rule, err)
+		}
+	}
+
+	type withComplex struct {
+		C complex64 `valid:"gt:1"`
+	}
+
+	err := New().Struct(withComplex{}).Validate()
+	if got := errString(err); got != "rule error: gt does not support complex numbers on withComplex.C." {
+		t.Errorf("struct: got %q", got)
+	}
+}
@@ -1,8 +1,11 @@
 package validator
 
 import (
-	_ "fmt"
+	"errors"
+	"fmt"
 	"reflect"
+	"sort"
+	"strconv"
 	"strings"
 )
 
@@ -11,244 +14,725 @@ const (
 	STR_REQUIRED  string = "required"  // 必须字符串
 	STR_UNDEFINE  string = "undefine"  // 未定义字符串
 	STR_SOMETIMES string = "sometimes" // 存在时字符串
+	STR_NULLABLE  string = "nullable"  // 可以为nil字符串
+	STR_FILLED    string = "filled"    // 存在时不能为空字符串
 	STR_DEFAULT   string = "default"   // 默认字符串
+	STR_BAIL      string = "bail"      // 首个规则失败后停止验证字段字符串
+	STR_DIVE      string = "dive"      // 逐个验证元素字符串
+	STR_KEYS      string = "keys"      // map key 规则开始字符串
+	STR_ENDKEYS   string = "endkeys"   // map key 规则结束字符串
+	STR_KEY_PATH  string = "#key"      // map key 错误路径后缀
 	STR_VALID     string = "valid"     // Tag验证关键字
+	STR_MSG       string = "msg"       // Tag自定义错误信息关键字
+	STR_LABEL     string = "label"     // Tag字段显示名称关键字
+	STR_SCENE     string = "@"         // 规则名称与验证场景分隔字符串
 
 	ERR_ATTR_FUNC      string = ":func"      // 函数占位符
 	ERR_ATTR_ATTRIBUTE string = ":attribute" // 属性字段占位符
 	ERR_ATTR_VALUE     string = ":value"     // 值占位符
+	ERR_ATTR_OTHER     string = ":other"     // 关联字段占位符
+	ERR_ATTR_VALUES    string = ":values"    // 值列表占位符
+	ERR_ATTR_DATE      string = ":date"      // 日期占位符
+	ERR_ATTR_FORMAT    string = ":format"    // 日期格式占位符
+	ERR_ATTR_SIZE      string = ":size"      // 大小占位符
+	ERR_ATTR_DIGITS    string = ":digits"    // 位数占位符
+	ERR_ATTR_MIN       string = ":min"       // 最小值占位符
+	ERR_ATTR_MAX       string = ":max"       // 最大值占位符
 )
 
-var (
-	// 设置相关的验证规则
-	ruleMap map[string]interface{}
-
-	// 设置字段的数据类型
-	typeMap map[string]interface{}
-
-	// 设置数据的值
-	dataMap map[string]interface{}
-)
-
+// Validator 验证器
+// 每个 Validator 各自持有规则、类型和数据，互不共享，因此不同的 Validator 可以在多个 goroutine 中并发使用；
+// 同一个 Validator 不能同时被多个 goroutine 使用
 type Validator struct {
 	// 是否验证通过
 	Fails bool
 
 	// 自定义验证方法
+	// Deprecated: 使用 RegisterRule 注册自定义验证规则
 	TagMap map[string]func(...reflect.Value) bool
 
 	// 设置错误信息
 	ErrorMsg map[string]string
+
+	// 验证错误列表
+	errors ValidationErrors
+
+	// 规则或数据的错误
+	err error
+
+	// 待验证的字段，按添加顺序排列
+	fields []*fieldData
+
+	// 字段标识到待验证字段的索引
+	fieldMap map[string]*fieldData
+
+	// 是否在字段的第一个规则验证失败后跳过该字段的其余规则，通过 WithBail 设置
+	bail bool
+
+	// 通过 RegisterRule 注册的自定义验证规则
+	rules map[string]RuleFunc
+
+	// 通过 SetMessages 设置的自定义错误信息，key 为规则名称或 字段.规则名称
+	messages map[string]string
+
+	// 查找错误信息的语言顺序
+	locales []string
+
+	// 通过 SetLabels 设置的字段显示名称
+	labels map[string]string
+
+	// 结构体字段的命名方式，nil 时使用字段名
+	namer FieldNamer
+
+	// 结构体字段的路径是否以结构体类型名称开头
+	rootName bool
+
+	// 验证规则 tag 的名称及分隔符
+	syntax tagSyntax
+
+	// 当前验证场景，为空时只验证未指定场景的规则
+	scenario string
+
+	// 通过 Only、Except 选择或排除的字段
+	only, except []string
+
+	// 是否只验证数据中存在的字段
+	onlyPresent bool
 }
 
-// New 实例化验证器
-func New() *Validator {
+// 待验证字段的类型、数据及规则
+type fieldData struct {
+	key       string
+	fieldType string
+	value     reflect.Value
+	parent    reflect.Value // 字段所在的结构体或 map 数据
+	root      reflect.Value // 最外层的结构体或 map 数据
+	rootKey   string        // 最外层数据的名称
+	rules     []*ruleItem
+	fieldMeta
+}
+
+// Option Validator 的选项
+type Option func(v *Validator)
+
+// WithBail 所有字段在第一个规则验证失败后跳过其余规则，等同于每个字段都添加了bail规则
+func WithBail() Option {
+	return func(v *Validator) {
+		v.bail = true
+	}
+}
+
+// New 实例化验证器，错误信息默认使用英文，可以通过 WithLocale、WithBail 等选项设置，如 New(WithBail())
+func New(opts ...Option) *Validator {
 	validator := &Validator{
 		Fails:    true,
 		TagMap:   make(map[string]func(...reflect.Value) bool),
 		ErrorMsg: make(map[string]string),
+		fieldMap: make(map[string]*fieldData),
+		rules:    make(map[string]RuleFunc),
+		messages: make(map[string]string),
+		locales:  localeChain(nil),
+		labels:   make(map[string]string),
+		rootName: true,
+		syntax:   defaultSyntax,
 	}
 
-	ruleMap = make(map[string]interface{})
-	typeMap = make(map[string]interface{})
-	dataMap = make(map[string]interface{})
+	for _, opt := range opts {
+		opt(validator)
+	}
 
 	return validator
 }
 
-// Struct 结构体验证
+// Struct 结构体验证，结构体的验证规则按类型编译并缓存
+// 嵌套的结构体、结构体指针和嵌入结构体会递归验证，错误路径格式如：Order.Address.City
+// obj 可以是结构体或结构体指针，传入 nil、nil 指针或非结构体时，Validate 返回相应的错误
 func (v *Validator) Struct(obj interface{}) *Validator {
-	objT := reflect.TypeOf(obj)
-	objV := reflect.ValueOf(obj)
+	objV, ptrV, err := structValue(obj)
+	if err != nil {
+		v.setError(err)
+		return v
+	}
 
-	v.parseData(objT, objV)
+	schema, err := schemaForSyntax(objV.Type(), v.syntax)
+	if err != nil {
+		v.setError(err)
+		return v
+	}
+
+	v.setError(v.bindStruct(schema, objV, ptrV))
 
 	return v
 }
 
-// Validate 执行验证
-func (v *Validator) Validate() {
-	v.doParse()
+// StructWithSchema 使用已编译的 Schema 验证结构体，跳过按类型查找缓存，如 v.StructWithSchema(schema, &u).Validate()
+// obj 的类型必须与 Schema 的结构体类型一致，规则的 tag 语法以编译 Schema 时的选项为准
+func (v *Validator) StructWithSchema(schema *Schema, obj interface{}) *Validator {
+	if schema == nil {
+		v.setError(errors.New("rule error: StructWithSchema requires a schema."))
+		return v
+	}
+
+	objV, ptrV, err := structValue(obj)
+	if err != nil {
+		v.setError(err)
+		return v
+	}
+
+	if objV.Type() != schema.typ {
+		v.setError(errors.New("data error: Schema of " + schema.typ.String() + " does not match " + objV.Type().String() + "."))
+		return v
+	}
+
+	v.setError(v.bindStruct(schema, objV, ptrV))
+
+	return v
 }
 
-// 数据解析处理
-func (v *Validator) parseData(objT reflect.Type, objV reflect.Value) {
-	objName := objT.Name()
+// 获取结构体或结构体指针指向的结构体，obj 为指针时同时返回指向结构体的指针
+func structValue(obj interface{}) (objV, ptrV reflect.Value, err error) {
+	objV = reflect.ValueOf(obj)
+	for objV.Kind() == reflect.Ptr {
+		if objV.IsNil() {
+			return objV, ptrV, errors.New("data error: Struct received a nil pointer.")
+		}
 
-	for i := 0; i < objT.NumField(); i++ {
-		ruleKey := objName + "." + objT.Field(i).Name
+		ptrV, objV = objV, objV.Elem()
+	}
 
-		ruleVal := objT.Field(i).Tag.Get(STR_VALID)
-		ruleVal = strings.TrimSpace(ruleVal)
+	if objV.Kind() != reflect.Struct {
+		return objV, ptrV, errors.New("data error: Struct requires a struct or a pointer to struct.")
+	}
 
-		typeMap[ruleKey+".type"] = objT.Field(i).Type.Kind().String()
-		dataMap[ruleKey+".val"] = objV.Field(i)
+	return objV, ptrV, nil
+}
 
-		v.parseRule(ruleKey, ruleVal)
+// 按 Schema 绑定结构体的字段，ptrV 为结构体指针时标记为已访问，字段中指回自身的指针不再重复验证
+func (v *Validator) bindStruct(schema *Schema, objV, ptrV reflect.Value) error {
+	rootKey := ""
+	if v.rootName {
+		rootKey = schema.typ.Name()
 	}
+
+	state := newBindState(objV, rootKey, schema.syntax)
+	if ptrV.IsValid() {
+		state.visited[visitKey{ptrV.Pointer(), ptrV.Type()}] = true
+	}
+
+	return v.parseData(schema, objV, rootKey, state)
 }
 
-// 解析规则，把字符串通过分隔符转换成规则map
-func (v *Validator) parseRule(ruleKey string, rules string) {
-	if rules == "" || len(rules) <= 0 {
-		panic("rule error: Missing validation rules.")
+// Validate 执行验证，验证不通过时返回 ValidationErrors
+// 规则或数据有误（如规则格式错误、传入nil指针）时不执行验证，直接返回相应的错误
+// 验证方法发生 panic 时同样转为错误返回
+// locales 为本次验证错误信息的语言及回退语言，之后回退到 WithLocale 设置的语言
+func (v *Validator) Validate(locales ...string) (err error) {
+	if v.err != nil {
+		return v.err
 	}
 
-	ruleArr := strings.Split(rules, "|")
+	// Only、Except 中的字段名称有误时不执行验证
+	if err := v.checkSelection(); err != nil {
+		v.setError(err)
+		return v.err
+	}
 
-	for _, rule := range ruleArr {
-		var tempKey string
-		val := STR_NULL
+	if len(locales) > 0 {
+		defer func(saved []string) { v.locales = saved }(v.locales)
+		v.locales = localeChain(append(append([]string{}, locales...), v.locales...))
+	}
 
-		pos := strings.IndexAny(rule, ":")
-		if pos != -1 {
-			tempKey = rule[:pos]
-			val = rule[pos+1:]
-		} else {
-			tempKey = rule
+	defer func() {
+		if r := recover(); r != nil {
+			v.setError(fmt.Errorf("rule error: %v", r))
+			err = v.err
 		}
+	}()
 
-		// 去除相关空白字符
-		tempKey = strings.TrimSpace(tempKey)
-		val = strings.TrimSpace(val)
+	v.doParse()
 
-		ruleMap[ruleKey+"."+tempKey] = val
+	if len(v.errors) > 0 {
+		return v.errors
 	}
+
+	return nil
 }
 
-// 执行解析
-// 解析优先使用rule中的相关方法，如果不存在看是否存在用户自定义的方法，如果都没有则返回false，并添加到相关的错误中
-func (v *Validator) doParse() {
-	if ruleMap != nil && typeMap != nil {
-		rule := NewRule()
-		rT := reflect.TypeOf(rule)
+// 记录规则或数据的错误，只保留第一个错误
+func (v *Validator) setError(err error) {
+	if err != nil && v.err == nil {
+		v.err = err
+	}
+}
 
-		for key, val := range ruleMap {
-			pos := strings.LastIndexAny(key, ".")
+// Errors 返回验证错误列表
+func (v *Validator) Errors() ValidationErrors {
+	return v.errors
+}
 
-			method := Ucfirst(key[pos+1:])
-			fieldKey := key[:pos]
+// 已访问的结构体指针，用于防止自引用的指针循环验证
+type visitKey struct {
+	ptr uintptr
+	typ reflect.Type
+}
 
-			fieldType := typeMap[fieldKey+".type"]
-			fieldTemp := dataMap[fieldKey+".val"]
+// 绑定数据时的状态
+type bindState struct {
+	root    reflect.Value     // 最外层的结构体或 map 数据
+	rootKey string            // 最外层数据的名称，结构体为类型名称
+	visited map[visitKey]bool // 当前路径上已访问的结构体指针
+	syntax  tagSyntax         // 嵌套结构体使用的 tag 语法
+}
 
-			fieldVal, _ := fieldTemp.(reflect.Value)
+// 创建绑定状态
+func newBindState(root reflect.Value, rootKey string, syntax tagSyntax) *bindState {
+	return &bindState{
+		root:    root,
+		rootKey: rootKey,
+		visited: make(map[visitKey]bool),
+		syntax:  syntax,
+	}
+}
 
-			// 检查传的值是否有效
-			if !fieldVal.IsValid() {
-				v.AddErrorMsg(key, strings.ToLower(method), STR_NULL, fieldType)
-				continue
+// 数据解析处理，按 Schema 绑定结构体中每个字段的数据，嵌套的结构体递归处理
+func (v *Validator) parseData(schema *Schema, objV reflect.Value, prefix string, state *bindState) error {
+	for _, field := range schema.fields {
+		fieldKey := joinKey(prefix, v.fieldName(field.structField))
+		fieldV := objV.Field(field.index)
+
+		if len(field.rules) > 0 {
+			// 内置规则在编译 Schema 时已确定，只需检查自定义规则
+			if field.custom {
+				if err := v.checkRuleNames(fieldKey, field.rules); err != nil {
+					return err
+				}
 			}
 
-			callMethod, exist := rT.MethodByName(method)
+			if err := v.bindField(fieldKey, field.fieldType, fieldV, objV, field.rules, field.fieldMeta, state); err != nil {
+				return err
+			}
+		}
 
-			if exist {
-				// 固定参数
-				params := make([]reflect.Value, 4)
+		if !field.nested {
+			continue
+		}
 
-				// 指定使用的验证规则，参数是方法名
-				params[0] = reflect.ValueOf(rule)
+		// 嵌入字段的子字段展开到当前层级
+		if field.embedded {
+			fieldKey = prefix
+		}
 
-				// 指定验证规则内容
-				params[1] = reflect.ValueOf(val)
+		if err := v.bindNested(fieldV, fieldKey, state); err != nil {
+			return err
+		}
+	}
 
-				// 数据类型，字符串
-				params[2] = reflect.ValueOf(fieldType)
+	return nil
+}
 
-				// 待验证的数据
-				params[3] = reflect.ValueOf(fieldVal)
+// 递归处理嵌套的结构体或结构体指针
+func (v *Validator) bindNested(objV reflect.Value, prefix string, state *bindState) error {
+	if objV.Kind() == reflect.Ptr {
+		// nil 指针不再向下验证，由字段自身的规则处理
+		if objV.IsNil() {
+			return nil
+		}
 
-				// 通过反射调用已封装好的验证方法
-				retArr := callMethod.Func.Call(params)
-				ret := retArr[0].Bool()
-				if ret == false {
-					v.AddErrorMsg(key, method, val, fieldType)
-				}
-			} else {
-				// 方法名统一转化为小写
-				lowerMethod := strings.ToLower(method)
-				defineFunc, isSet := v.TagMap[lowerMethod]
-				if isSet {
-					// 执行用户自定义的验证方法,
-					// 第一个参数验证规则具体内容
-					// 第二个参数字段数据类型
-					// 第三个参数待验证的数据
-					ret := defineFunc(reflect.ValueOf(val), reflect.ValueOf(fieldType), fieldVal)
-					if ret == false {
-						v.AddErrorMsg(key, lowerMethod, val, fieldType)
-					}
-				} else {
-					v.AddFuncErrorMsg(key, lowerMethod)
+		key := visitKey{objV.Pointer(), objV.Type()}
+		if state.visited[key] {
+			return nil
+		}
+
+		state.visited[key] = true
+		defer delete(state.visited, key)
+
+		objV = objV.Elem()
+	}
+
+	schema, err := schemaForSyntax(objV.Type(), state.syntax)
+	if err != nil {
+		return err
+	}
+
+	return v.parseData(schema, objV, prefix, state)
+}
+
+// 绑定字段的数据及规则，包含dive规则时，dive之前的规则验证字段本身，之后的规则逐个验证元素
+func (v *Validator) bindField(fieldKey, fieldType string, value, parent reflect.Value, rules []*ruleItem, meta fieldMeta, state *bindState) error {
+	var dive *ruleItem
+	if n := len(rules); n > 0 && rules[n-1].name == STR_DIVE {
+		dive = rules[n-1]
+		rules = rules[:n-1]
+	}
+
+	if err := checkComplex(fieldKey, fieldType, value, rules); err != nil {
+		return err
+	}
+
+	if len(rules) > 0 || dive == nil {
+		v.addField(fieldKey, fieldType, value, parent, rules, meta, state)
+	}
+
+	if dive == nil {
+		return nil
+	}
+
+	return v.bindDive(fieldKey, value, dive, meta, state)
+}
+
+// 绑定数组、切片、map的每个元素，元素路径格式如：Tags[3]、Limits[foo]，map key 的路径格式如：Limits[foo]#key
+func (v *Validator) bindDive(fieldKey string, value reflect.Value, dive *ruleItem, meta fieldMeta, state *bindState) error {
+	value = indirectValue(value)
+	if !value.IsValid() {
+		return nil
+	}
+
+	switch value.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			elemKey := fieldKey + "[" + strconv.Itoa(i) + "]"
+			if err := v.bindElem(elemKey, value.Index(i), value, dive.each, meta.elem(elemKey[len(fieldKey):]), state); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		keys := value.MapKeys()
+		sort.Slice(keys, func(i, j int) bool {
+			return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+		})
+
+		for _, key := range keys {
+			elemKey := fieldKey + "[" + fmt.Sprint(key.Interface()) + "]"
+			if len(dive.keys) > 0 {
+				// key 使用单独的路径，与值的错误互不覆盖
+				if err := v.bindElem(elemKey+STR_KEY_PATH, key, value, dive.keys, meta.elem(elemKey[len(fieldKey):]), state); err != nil {
+					return err
 				}
 			}
+
+			if err := v.bindElem(elemKey, value.MapIndex(key), value, dive.each, meta.elem(elemKey[len(fieldKey):]), state); err != nil {
+				return err
+			}
 		}
+	default:
+		return errors.New("rule error: dive requires an array, slice or map, " + fieldKey + " is " + value.Kind().String() + ".")
 	}
+
+	return nil
 }
 
-// AddRule 逐条添加指定的验证规则
-func (v *Validator) AddRule(fieldKey, fieldType, ruleStr string, dataVal interface{}) *Validator {
-	typeMap[fieldKey+".type"] = fieldType
-	dataMap[fieldKey+".val"] = reflect.ValueOf(dataVal)
+// 绑定单个元素，元素为包含验证规则的结构体时递归验证
+func (v *Validator) bindElem(elemKey string, elemV, parent reflect.Value, rules []*ruleItem, meta fieldMeta, state *bindState) error {
+	if elemV.Kind() == reflect.Interface && !elemV.IsNil() {
+		elemV = elemV.Elem()
+	}
 
-	v.parseRule(fieldKey, ruleStr)
+	if len(rules) > 0 {
+		if err := v.bindField(elemKey, elemV.Kind().String(), elemV, parent, rules, meta, state); err != nil {
+			return err
+		}
+	}
 
-	return v
+	if elemT, ok := structType(elemV.Type()); ok && isValidStruct(elemT, state.syntax.tag) {
+		return v.bindNested(elemV, elemKey, state)
+	}
+
+	return nil
 }
 
-// AddMapRule 批量通过map添加指定验证规则
-func (v *Validator) AddMapRule(ruleMap map[string][]string, dataVal map[string]interface{}) *Validator {
-	for key, tag := range ruleMap {
-		if len(tag) < 2 {
-			panic("rule error: At least two " + key + " elements.")
+// 添加待验证字段
+func (v *Validator) addField(fieldKey, fieldType string, value, parent reflect.Value, rules []*ruleItem, meta fieldMeta, state *bindState) {
+	field := &fieldData{
+		key:       fieldKey,
+		fieldType: fieldType,
+		value:     value,
+		parent:    parent,
+		root:      state.root,
+		rootKey:   state.rootKey,
+		rules:     rules,
+		fieldMeta: meta,
+	}
+
+	v.fields = append(v.fields, field)
+
+	if _, ok := v.fieldMap[fieldKey]; !ok {
+		v.fieldMap[fieldKey] = field
+	}
+}
+
+// 执行解析
+// 字段按添加顺序（结构体按字段声明顺序）验证，同一字段的规则按书写顺序验证，错误按同样的顺序记录
+// 解析优先使用rule中的相关方法，如果不存在看是否存在用户自定义的方法，如果都没有则返回false，并添加到相关的错误中
+// 每次验证前清除上一次验证的错误，绑定的数据修改后可以再次验证
+func (v *Validator) doParse() {
+	v.Fails = true
+	v.ErrorMsg = make(map[string]string)
+	v.errors = nil
+
+	rule := NewRule()
+
+	for _, field := range v.fields {
+		if !v.selected(field) {
+			continue
 		}
 
-		tempData, ok := dataVal[key]
-		var data interface{}
-		if ok && reflect.ValueOf(tempData).IsValid() {
-			data = tempData
-		} else {
-			if v.ContainSometimes(tag[1]) {
+		// 只保留当前场景下生效的规则，规则均不生效的字段不验证
+		if rules := v.sceneRules(field.rules); len(rules) != len(field.rules) {
+			if len(rules) == 0 {
 				continue
 			}
 
-			if v.ContainRequired(tag[1]) {
-				v.AddErrorMsg(key+".required", STR_REQUIRED, STR_NULL, nil)
-				continue
+			scoped := *field
+			scoped.rules = rules
+			field = &scoped
+		}
+
+		// 字段包含bail规则或开启了WithBail时，第一个规则验证失败后跳过其余规则
+		bail := v.bail || hasRule(field.rules, STR_BAIL)
+
+		// 字段不存在或为 nil 时只执行required系列规则，map 数据的字段包含sometimes规则时跳过
+		if !field.value.IsValid() || isNilValue(field.value) {
+			if field.parent.Kind() != reflect.Map || !hasRule(field.rules, STR_SOMETIMES) {
+				v.checkMissing(rule, field, bail)
+			}
+			continue
+		}
+
+		elem := field.elem()
+		for _, item := range field.rules {
+			// required 系列规则需要区分 nil 与零值，使用字段的原始值验证，其他规则使用指针、接口指向的值验证
+			target := elem
+			if rawValueRules(item.name) {
+				target = field
 			}
 
-			v.AddErrorMsg(key, STR_NULL, STR_NULL, nil)
+			if !v.checkRule(rule, target, item) && bail {
+				break
+			}
+		}
+	}
+}
+
+// 指针、接口字段指向的值，字段类型为 ptr 或 interface 时同时替换为指向的值的类型
+func (field *fieldData) elem() *fieldData {
+	if kind := field.value.Kind(); kind != reflect.Ptr && kind != reflect.Interface {
+		return field
+	}
+
+	elem := *field
+	elem.value = indirectValue(field.value)
+	if field.fieldType == reflect.Ptr.String() || field.fieldType == reflect.Interface.String() {
+		elem.fieldType = elem.value.Kind().String()
+	}
+
+	return &elem
+}
+
+// 验证不存在或为 nil 的字段，没有required系列规则时添加字段值不存在的错误
+// required系列规则均验证通过（如 required_if 条件不满足）时，字段可以不存在，跳过其余规则
+// 字段不存在时 filled 规则验证通过；字段为 nil 时 filled 规则验证不通过，nullable 规则验证通过
+func (v *Validator) checkMissing(rule *Rules, field *fieldData, bail bool) {
+	isNil := field.value.IsValid()
+	implicit := false
+
+	for _, item := range field.rules {
+		if !implicitRules[item.name] && !(isNil && item.name == STR_FILLED) {
+			continue
+		}
+
+		implicit = true
+		if !v.checkRule(rule, field, item) && bail {
+			break
+		}
+	}
+
+	switch {
+	case implicit, hasRule(field.rules, STR_SOMETIMES):
+	case !isNil && hasRule(field.rules, STR_FILLED):
+	case isNil && hasRule(field.rules, STR_NULLABLE):
+	default:
+		errMsg := v.fieldMessage(field, STR_NULL, STR_NULL)
+		if tpl, ok := v.customMessage(field, STR_NULL); ok {
+			errMsg = v.renderField(tpl, field, STR_NULL, STR_NULL)
+		}
+
+		v.addFieldError(field.key, &FieldError{
+			Field:   field.key,
+			Rule:    STR_NULL,
+			Type:    field.fieldType,
+			Message: errMsg,
+		})
+	}
+}
+
+// 验证字段的单条规则，验证不通过时记录错误并返回false
+func (v *Validator) checkRule(rule *Rules, field *fieldData, item *ruleItem) bool {
+	fieldKey := field.key
+
+	// 方法名统一转化为小写
+	lowerMethod := strings.ToLower(item.name)
+
+	if item.fn != nil {
+		// 调用编译时已确定的验证方法
+		if !item.fn(rule, item.param, field.fieldType, field.value) {
+			v.fail(fieldKey, item, field, v.fieldMessage(field, lowerMethod, item.param))
+			return false
+		}
+
+		return true
+	}
+
+	// 内置字段关联验证方法优先，其次执行通过 RegisterRule 注册的自定义验证方法
+	ruleFn, ok := item.fieldFn, item.fieldFn != nil
+	if !ok {
+		ruleFn, ok = v.lookupRule(lowerMethod)
+	}
+
+	if ok {
+		err := ruleFn(v.newFieldContext(field, item))
+		if err == nil {
+			return true
+		}
+
+		errMsg := err.Error()
+		if errors.Is(err, ErrInvalid) {
+			errMsg = v.fieldMessage(field, lowerMethod, item.param)
+		}
+
+		v.fail(fieldKey, item, field, errMsg)
+		return false
+	}
+
+	defineFunc, isSet := v.TagMap[lowerMethod]
+	if !isSet {
+		v.fail(fieldKey, item, field, v.undefineMessage(lowerMethod))
+		return false
+	}
+
+	// 执行用户自定义的验证方法,
+	// 第一个参数验证规则具体内容
+	// 第二个参数字段数据类型
+	// 第三个参数待验证的数据
+	ret := defineFunc(reflect.ValueOf(item.param), reflect.ValueOf(field.fieldType), field.value)
+	if ret == false {
+		v.fail(fieldKey, item, field, v.fieldMessage(field, lowerMethod, item.param))
+		return false
+	}
+
+	return true
+}
+
+// AddRule 逐条添加指定的验证规则
+// messages 为该字段的自定义错误信息，格式与 msg tag 相同，如 "required:请输入用户名|range:用户名长度为 :value 个字符"
+func (v *Validator) AddRule(fieldKey, fieldType, ruleStr string, dataVal interface{}, messages ...string) *Validator {
+	return v.addRule(fieldKey, fieldType, ruleStr, messages, reflect.ValueOf(dataVal), reflect.Value{})
+}
+
+// 添加验证规则，parent 为字段所在的 map 数据
+func (v *Validator) addRule(fieldKey, fieldType, ruleStr string, msgStrs []string, value, parent reflect.Value) *Validator {
+	rules, err := parseRule(ruleStr, v.syntax)
+	if err == nil {
+		err = v.checkRuleNames(fieldKey, rules)
+	}
+
+	var messages map[string]string
+	if err == nil {
+		messages, err = parseMessages(v.syntax, msgStrs...)
+	}
+
+	if err != nil {
+		v.setError(err)
+		return v
+	}
+
+	// 同一字段多次添加时，规则依次追加，字段保持第一次添加时的位置
+	if field, ok := v.fieldMap[fieldKey]; ok && !hasRule(rules, STR_DIVE) {
+		field.fieldType = fieldType
+		field.value = value
+		field.rules = append(field.rules[:len(field.rules):len(field.rules)], rules...)
+		field.messages = mergeMessages(field.messages, messages)
+		return v
+	}
+
+	v.setError(v.bindField(fieldKey, fieldType, value, parent, rules, fieldMeta{messages: messages}, newBindState(parent, "", v.syntax)))
+
+	return v
+}
 
+// AddMapRule 批量通过map添加指定验证规则，字段按key排序后依次添加
+// ruleMap 的值依次为字段类型、验证规则及可选的自定义错误信息，如 []string{"string", "required", "required:请输入用户名"}
+func (v *Validator) AddMapRule(ruleMap map[string][]string, dataVal map[string]interface{}) *Validator {
+	keys := make([]string, 0, len(ruleMap))
+	for key := range ruleMap {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		tag := ruleMap[key]
+		if len(tag) < 2 {
+			v.setError(errors.New("rule error: At least two " + key + " elements."))
 			continue
 		}
 
-		v.AddRule(key, tag[0], tag[1], data)
+		// 字段不存在时，在验证时按当前场景的规则判断是否跳过，或由required系列规则处理
+		// 使用 map 中的接口值，key 不存在时为无效值，值为 nil 时为 nil 接口，其他值视为已填写
+		mapV := reflect.ValueOf(dataVal)
+		v.addRule(key, tag[0], tag[1], tag[2:], mapV.MapIndex(reflect.ValueOf(key)), mapV)
 	}
 
 	return v
 }
 
+// 添加规则验证失败的错误
+func (v *Validator) fail(fieldKey string, item *ruleItem, field *fieldData, errMsg string) {
+	method := strings.ToLower(item.name)
+	if tpl, ok := v.customMessage(field, method); ok {
+		errMsg = v.renderField(tpl, field, method, item.param)
+	}
+
+	param := item.param
+	if param == STR_NULL {
+		param = ""
+	}
+
+	v.addFieldError(fieldKey+"."+item.name, &FieldError{
+		Field:   fieldKey,
+		Rule:    method,
+		Param:   param,
+		Value:   valueInterface(field.value),
+		Type:    field.fieldType,
+		Message: errMsg,
+	})
+}
+
+// 添加错误到错误列表，同一个key只保留第一条错误
+func (v *Validator) addFieldError(key string, fe *FieldError) {
+	v.Fails = false
+
+	_, ok := v.ErrorMsg[key]
+	if !ok {
+		v.ErrorMsg[key] = fe.Message
+		v.errors = append(v.errors, fe)
+	}
+}
+
 // AddFuncErrorMsg 添加未定义func错误信息
 func (v *Validator) AddFuncErrorMsg(fieldKey, attribute interface{}) {
 	keyStr := reflect.ValueOf(fieldKey).String()
 	method := reflect.ValueOf(attribute).String()
 	method = strings.ToLower(method)
 
-	errMsg := ""
-	errStr, ok := ruleErrorMsgMap[STR_UNDEFINE]
-
-	if ok {
-		errMsg = reflect.ValueOf(errStr).String()
-		errMsg = strings.Replace(errMsg, ERR_ATTR_FUNC, method, -1)
-	} else {
-		errMsg = "The func " + method + "() is not defined."
+	filedStr := keyStr
+	if pos := strings.LastIndex(keyStr, "."); pos != -1 {
+		filedStr = keyStr[:pos]
 	}
 
-	v.Fails = false
-
-	_, err := v.ErrorMsg[keyStr]
-	if !err {
-		v.ErrorMsg[keyStr] = errMsg
-	}
+	v.addFieldError(keyStr, &FieldError{
+		Field:   filedStr,
+		Rule:    method,
+		Message: v.undefineMessage(method),
+	})
 }
 
 // AddErrorMsg 添加错误信息到error map中
@@ -256,80 +740,139 @@ func (v *Validator) AddErrorMsg(fieldKey, attribute, value, filedType interface{
 	keyStr := reflect.ValueOf(fieldKey).String()
 	valStr := reflect.ValueOf(value).String()
 	method := reflect.ValueOf(attribute).String()
+	typeStr, _ := filedType.(string)
 
 	method = strings.ToLower(method)
 	filedStr := strings.Replace(keyStr, "."+method, "", -1)
 
-	errMsg := ""
-	errStr, exits := ruleErrorMsgMap[method]
+	param := valStr
+	if param == STR_NULL {
+		param = ""
+	}
+
+	v.addFieldError(keyStr, &FieldError{
+		Field:   filedStr,
+		Rule:    method,
+		Param:   param,
+		Type:    typeStr,
+		Message: v.errorMessage(filedStr, method, valStr, typeStr),
+	})
+}
 
-	if exits {
-		asType := getTypeMapping(reflect.ValueOf(filedType).String())
+// 生成未定义func的错误信息
+func (v *Validator) undefineMessage(method string) string {
+	if errMsg, ok := v.catalogMessage(STR_UNDEFINE, ""); ok {
+		return strings.Replace(errMsg, ERR_ATTR_FUNC, method, -1)
+	}
 
-		var msgIndex = "string"
-		switch errStr.(type) {
-		case string:
-			msgIndex = "string"
-		default:
-			msgIndex = "noString"
-		}
+	return "The func " + method + "() is not defined."
+}
+
+// 错误信息中的占位符及替换内容，:values 需要在 :value 之前替换
+// 关联字段规则的 :other 为第一个参数；required_if 的 :value、required_unless 的 :values 为其余参数；
+// required_with 系列规则的 :values 为全部参数；max 规则及只有一个参数的 bytes、width 规则的 :max 为参数，range 等规则的 :min、:max 为两个参数
+// label 把参数中的字段名称转换为显示名称，为 nil 时使用原名称
+func messageArgs(filedStr, method, valStr string, label func(name string) string) []string {
+	if label == nil {
+		label = func(name string) string { return name }
+	}
 
-		if msgIndex == "noString" {
-			tempMap := errStr.(map[string]string)
-			errMsg = tempMap[asType]
-		} else {
-			errMsg = reflect.ValueOf(errStr).String()
+	var params []string
+	if valStr != STR_NULL {
+		for _, param := range strings.Split(valStr, ",") {
+			params = append(params, strings.TrimSpace(param))
 		}
+	}
 
-		errMsg = strings.Replace(errMsg, ERR_ATTR_ATTRIBUTE, filedStr, -1)
-		errMsg = strings.Replace(errMsg, ERR_ATTR_VALUE, valStr, -1)
-	} else {
-		defaultStr, ok := ruleErrorMsgMap[STR_DEFAULT]
-		if ok {
-			errMsg = reflect.ValueOf(defaultStr).String()
-			errMsg = strings.Replace(errMsg, ERR_ATTR_ATTRIBUTE, filedStr, -1)
-		} else {
-			errMsg = "The " + filedStr + " is invalid."
+	first, rest := valStr, []string(nil)
+	if len(params) > 0 {
+		first, rest = params[0], params[1:]
+	}
+
+	other, value, values, date := label(first), valStr, strings.Join(params, ", "), label(valStr)
+	min, max := first, strings.Join(rest, ", ")
+
+	switch method {
+	case "required_if":
+		value = strings.Join(rest, ", ")
+	case "required_unless":
+		values = strings.Join(rest, ", ")
+	case "required_with", "required_with_all", "required_without", "required_without_all":
+		labels := make([]string, 0, len(params))
+		for _, param := range params {
+			labels = append(labels, label(param))
 		}
+
+		values = strings.Join(labels, ", ")
+	case "max":
+		min, max = "", valStr
 	}
 
-	v.Fails = false
+	if maxOnlyRules[method] && maxOnlyParam(valStr) {
+		min, max = "", valStr
+	}
 
-	_, ok := v.ErrorMsg[keyStr]
-	if !ok {
-		v.ErrorMsg[keyStr] = errMsg
+	return []string{
+		ERR_ATTR_ATTRIBUTE, filedStr,
+		ERR_ATTR_OTHER, other,
+		ERR_ATTR_VALUES, values,
+		ERR_ATTR_VALUE, value,
+		ERR_ATTR_DATE, date,
+		ERR_ATTR_FORMAT, valStr,
+		ERR_ATTR_SIZE, valStr,
+		ERR_ATTR_DIGITS, valStr,
+		ERR_ATTR_MIN, min,
+		ERR_ATTR_MAX, max,
 	}
 }
 
-// ContainRequired 验证规则是否包含required
-func (v *Validator) ContainRequired(sRule string) bool {
-	str := string([]rune(sRule))
-	pos := strings.Index(str, STR_REQUIRED)
-	if pos != -1 {
-		return true
+// 替换错误信息中的占位符
+func renderMessage(tpl, filedStr, method, valStr string) string {
+	return strings.NewReplacer(messageArgs(filedStr, method, valStr, nil)...).Replace(tpl)
+}
+
+// 根据验证规则生成错误信息，按语言的查找顺序查找规则的错误信息，没有时使用默认错误信息
+func (v *Validator) errorMessage(filedStr, method, valStr, filedType string) string {
+	return renderMessage(v.messageTemplate(method, filedType), filedStr, method, valStr)
+}
+
+// 查找规则的错误信息模板，没有时使用默认错误信息
+func (v *Validator) messageTemplate(method, filedType string) string {
+	if tpl, ok := v.catalogMessage(method, filedType); ok {
+		return tpl
+	}
+
+	if tpl, ok := v.catalogMessage(STR_DEFAULT, ""); ok {
+		return tpl
 	}
 
-	return false
+	return "The " + ERR_ATTR_ATTRIBUTE + " is invalid."
+}
+
+// ContainRequired 验证规则是否包含required，按规则名称匹配，required_if 等规则不计入
+func (v *Validator) ContainRequired(sRule string) bool {
+	rules, err := parseRule(sRule, v.syntax)
+
+	return err == nil && hasRule(v.sceneRules(rules), STR_REQUIRED)
 }
 
 // ContainSometimes 验证规则是否包含sometimes
 func (v *Validator) ContainSometimes(sRule string) bool {
-	str := string([]rune(sRule))
-	pos := strings.Index(str, STR_SOMETIMES)
+	rules, err := parseRule(sRule, v.syntax)
 
-	if pos != -1 {
-		return true
-	}
-
-	return false
+	return err == nil && hasRule(v.sceneRules(rules), STR_SOMETIMES)
 }
 
-// ClearError 清除验证
+// ClearError 清除验证，Scenario 设置的验证场景及 Only、Except、OnlyPresent 设置的字段选择一并清除
 func (v *Validator) ClearError() {
 	v.Fails = true
+	v.scenario = ""
+	v.only = nil
+	v.except = nil
+	v.onlyPresent = false
 	v.ErrorMsg = make(map[string]string)
-
-	ruleMap = make(map[string]interface{})
-	typeMap = make(map[string]interface{})
-	dataMap = make(map[string]interface{})
+	v.errors = nil
+	v.err = nil
+	v.fields = nil
+	v.fieldMap = make(map[string]*fieldData)
 }
@@ -0,0 +1,460 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+type concurrentUser struct {
+	Name  string `valid:"required|range:2,8"`
+	Email string `valid:"required|email"`
+	Age   int    `valid:"range:1,120"`
+}
+
+// 错误列表中的字段及规则，格式为 字段.规则
+func errorKeys(err error) []string {
+	var errs ValidationErrors
+	if !errors.As(err, &errs) {
+		return nil
+	}
+
+	keys := make([]string, 0, len(errs))
+	for _, fe := range errs {
+		keys = append(keys, fe.Field+"."+fe.Rule)
+	}
+
+	return keys
+}
+
+func equalKeys(got, want []string) bool {
+	if len(got) != len(want) {
+		return false
+	}
+
+	for i := range got {
+		if got[i] != want[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func TestValidatorsAreIndependent(t *testing.T) {
+	tests := []struct {
+		name string
+		user concurrentUser
+		want []string
+	}{
+		{"valid", concurrentUser{"tom", "tom@example.com", 20}, nil},
+		{"empty", concurrentUser{}, []string{"concurrentUser.Name.required", "concurrentUser.Name.range", "concurrentUser.Email.required", "concurrentUser.Email.email", "concurrentUser.Age.range"}},
+		{"bad email", concurrentUser{"tom", "tom", 20}, []string{"concurrentUser.Email.email"}},
+	}
+
+	// 交替使用两个验证器，验证规则、数据及错误互不影响
+	a, b := New(), New()
+	for _, tt := range tests {
+		a.ClearError()
+		a.Struct(tt.user)
+		b.ClearError()
+		b.AddRule("Other", "string", "required", "")
+
+		if got := errorKeys(a.Validate()); !equalKeys(got, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+
+		if got := errorKeys(b.Validate()); !equalKeys(got, []string{"Other.required"}) {
+			t.Errorf("%s: other validator got %v", tt.name, got)
+		}
+	}
+}
+
+// go test -race 下验证多个 goroutine 各自使用 Validator 时没有数据竞争
+func TestConcurrentValidators(t *testing.T) {
+	const goroutines = 32
+	const rounds = 50
+
+	var wg sync.WaitGroup
+	errs := make(chan error, goroutines)
+
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+
+			for i := 0; i < rounds; i++ {
+				valid := (g+i)%2 == 0
+				user := concurrentUser{"tom", "tom@example.com", 20}
+				if !valid {
+					user.Email = fmt.Sprintf("user%d", g)
+				}
+
+				v := New()
+				err := v.Struct(user).AddRule("Code", "int", "range:1,9", g%10).Validate()
+
+				want := []string(nil)
+				if !valid {
+					want = append(want, "concurrentUser.Email.email")
+				}
+				if g%10 == 0 {
+					want = append(want, "Code.range")
+				}
+
+				if got := errorKeys(err); !equalKeys(got, want) {
+					errs <- fmt.Errorf("goroutine %d round %d: got %v, want %v", g, i, got, want)
+					return
+				}
+			}
+		}(g)
+	}
+
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Error(err)
+	}
+}
+
+type orderedUser struct {
+	Zip   string `valid:"required|numeric"`
+	Name  string `valid:"required|range:6,20|alpha"`
+	Email string `valid:"email|required"`
+}
+
+func TestDeclarationOrder(t *testing.T) {
+	tests := []struct {
+		name  string
+		build func(v *Validator) *Validator
+		want  []string
+	}{
+		{
+			"struct fields and tag order",
+			func(v *Validator) *Validator { return v.Struct(orderedUser{}) },
+			[]string{"orderedUser.Zip.required", "orderedUser.Zip.numeric", "orderedUser.Name.required", "orderedUser.Name.range", "orderedUser.Name.alpha", "orderedUser.Email.email", "orderedUser.Email.required"},
+		},
+		{
+			"AddRule order",
+			func(v *Validator) *Validator {
+				return v.AddRule("b", "string", "required|email", "").
+					AddRule("a", "string", "min:3", "").
+					AddRule("b", "string", "min:3", "")
+			},
+			[]string{"b.required", "b.email", "b.min", "a.min"},
+		},
+		{
+			"AddMapRule sorted by key",
+			func(v *Validator) *Validator {
+				return v.AddMapRule(map[string][]string{
+					"zeta":  {"string", "required"},
+					"alpha": {"string", "required|min:3"},
+					"mid":   {"int", "min:5"},
+				}, map[string]interface{}{"alpha": "", "mid": 1, "zeta": ""})
+			},
+			[]string{"alpha.required", "alpha.min", "mid.min", "zeta.required"},
+		},
+	}
+
+	for _, tt := range tests {
+		// 多次执行结果一致
+		for i := 0; i < 20; i++ {
+			if got := errorKeys(tt.build(New()).Validate()); !equalKeys(got, tt.want) {
+				t.Fatalf("%s run %d: got %v, want %v", tt.name, i, got, tt.want)
+			}
+		}
+	}
+}
+
+type bailUser struct {
+	Email string `valid:"bail|required|email|range:6,20"`
+	Name  string `valid:"required|alpha|range:6,20"`
+}
+
+func TestBail(t *testing.T) {
+	user := bailUser{}
+
+	tests := []struct {
+		name string
+		opts []Option
+		want []string
+	}{
+		{
+			"bail rule",
+			nil,
+			[]string{"bailUser.Email.required", "bailUser.Name.required", "bailUser.Name.alpha", "bailUser.Name.range"},
+		},
+		{
+			"validator option",
+			[]Option{WithBail()},
+			[]string{"bailUser.Email.required", "bailUser.Name.required"},
+		},
+	}
+
+	for _, tt := range tests {
+		if got := errorKeys(New(tt.opts...).Struct(user).Validate()); !equalKeys(got, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestBailStopsOnlyAfterFailure(t *testing.T) {
+	// 第一个规则通过时继续验证，之后的第一个失败后停止
+	err := New().AddRule("Email", "string", "bail|required|email|range:20,30", "a@b.cn").Validate()
+	if got := errorKeys(err); !equalKeys(got, []string{"Email.range"}) {
+		t.Errorf("got %v", got)
+	}
+
+	err = New().AddRule("Email", "string", "bail|required|email|range:20,30", "ab").Validate()
+	if got := errorKeys(err); !equalKeys(got, []string{"Email.email"}) {
+		t.Errorf("got %v", got)
+	}
+}
+
+type nestedBase struct {
+	Id int `valid:"required"`
+}
+
+type nestedAddress struct {
+	City string `valid:"required"`
+}
+
+type nestedOrder struct {
+	nestedBase
+	Address  nestedAddress
+	Shipping *nestedAddress `valid:"required"`
+	Billing  *nestedAddress
+}
+
+type cycleA struct {
+	Name string `valid:"required"`
+	B    *cycleB
+}
+
+type cycleB struct {
+	Name string `valid:"required"`
+	A    *cycleA
+}
+
+type cycleNode struct {
+	Name string `valid:"required"`
+	Next *cycleNode
+}
+
+func TestNestedStructs(t *testing.T) {
+	tests := []struct {
+		name string
+		obj  interface{}
+		want []string
+	}{
+		{
+			"nested, embedded and nil pointer",
+			nestedOrder{},
+			[]string{"nestedOrder.Id.required", "nestedOrder.Address.City.required", "nestedOrder.Shipping.required"},
+		},
+		{
+			"pointer fields",
+			&nestedOrder{nestedBase: nestedBase{Id: 1}, Address: nestedAddress{"x"}, Shipping: &nestedAddress{}, Billing: &nestedAddress{}},
+			[]string{"nestedOrder.Shipping.City.required", "nestedOrder.Billing.City.required"},
+		},
+	}
+
+	for _, tt := range tests {
+		if got := errorKeys(New().Struct(tt.obj).Validate()); !equalKeys(got, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestCyclicPointers(t *testing.T) {
+	a := &cycleA{}
+	a.B = &cycleB{A: a}
+
+	n := &cycleNode{}
+	n.Next = n
+
+	// 两个节点的环，从第二个节点开始验证
+	m := &cycleNode{Next: &cycleNode{}}
+	m.Next.Next = m
+
+	tests := []struct {
+		name string
+		obj  interface{}
+		want []string
+	}{
+		{"two types", a, []string{"cycleA.Name.required", "cycleA.B.Name.required"}},
+		{"self loop", n, []string{"cycleNode.Name.required"}},
+		{"two nodes", m, []string{"cycleNode.Name.required", "cycleNode.Next.Name.required"}},
+		// 传入结构体值时无法得知其地址，指回自身的指针再验证一次后停止
+		{"value", *n, []string{"cycleNode.Name.required", "cycleNode.Next.Name.required"}},
+	}
+
+	for _, tt := range tests {
+		if got := errorKeys(New().Struct(tt.obj).Validate()); !equalKeys(got, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+type diveItem struct {
+	Sku string `valid:"required"`
+}
+
+type divePost struct {
+	Tags   []string       `valid:"max:2|dive|email"`
+	Limits map[string]int `valid:"dive|keys|alphaDash|endkeys|min:0"`
+	Items  []*diveItem    `valid:"dive"`
+	Grid   [][]int        `valid:"dive|min:1|dive|max:9"`
+}
+
+func TestDive(t *testing.T) {
+	post := divePost{
+		Tags:   []string{"a@b.cn", "x", "y"},
+		Limits: map[string]int{"ok": 1, "bad key": 2, "neg": -1},
+		Items:  []*diveItem{{"a"}, {}},
+		Grid:   [][]int{{1, 10}, {}},
+	}
+
+	want := []string{
+		"divePost.Tags.max",
+		"divePost.Tags[1].email",
+		"divePost.Tags[2].email",
+		"divePost.Limits[bad key]#key.alpha_dash",
+		"divePost.Limits[neg].min",
+		"divePost.Items[1].Sku.required",
+		"divePost.Grid[0][1].max",
+		"divePost.Grid[1].min",
+	}
+
+	if got := errorKeys(New().Struct(post).Validate()); !equalKeys(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestDiveRuleSources(t *testing.T) {
+	tests := []struct {
+		name  string
+		build func(v *Validator) *Validator
+		want  []string
+	}{
+		{
+			"AddRule",
+			func(v *Validator) *Validator {
+				return v.AddRule("Emails", "slice", "required|dive|email", []string{"a@b.cn", "c"})
+			},
+			[]string{"Emails[1].email"},
+		},
+		{
+			"AddMapRule",
+			func(v *Validator) *Validator {
+				return v.AddMapRule(map[string][]string{
+					"scores": {"map", "dive|keys|alpha|endkeys|range:0,100"},
+				}, map[string]interface{}{"scores": map[string]int{"math": 120, "en": 90}})
+			},
+			[]string{"scores[math].range"},
+		},
+		{
+			"map key and value",
+			func(v *Validator) *Validator {
+				return v.AddRule("M", "map", "dive|keys|min:3|endkeys|min:3", map[string]string{"ab": "cd"})
+			},
+			[]string{"M[ab]#key.min", "M[ab].min"},
+		},
+		{
+			"not a collection",
+			func(v *Validator) *Validator { return v.AddRule("Name", "string", "dive|email", "x") },
+			nil,
+		},
+		{
+			"missing endkeys",
+			func(v *Validator) *Validator { return v.AddRule("M", "map", "dive|keys|alpha", map[string]int{}) },
+			nil,
+		},
+	}
+
+	for _, tt := range tests {
+		err := tt.build(New()).Validate()
+		if tt.want == nil {
+			if _, ok := err.(ValidationErrors); err == nil || ok {
+				t.Errorf("%s: expected a rule error, got %v", tt.name, err)
+			}
+			continue
+		}
+
+		if got := errorKeys(err); !equalKeys(got, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+type misuseUser struct {
+	Name    string `valid:"required"`
+	Comment string
+}
+
+func TestMisuseReturnsErrors(t *testing.T) {
+	var nilUser *misuseUser
+	user := &misuseUser{}
+	userPtr := &user
+
+	tests := []struct {
+		name    string
+		build   func(v *Validator) *Validator
+		want    []string
+		wantErr string
+	}{
+		{"pointer", func(v *Validator) *Validator { return v.Struct(&misuseUser{Name: "a"}) }, nil, ""},
+		{"pointer to pointer", func(v *Validator) *Validator { return v.Struct(userPtr) }, []string{"misuseUser.Name.required"}, ""},
+		{"nil", func(v *Validator) *Validator { return v.Struct(nil) }, nil, "data error: Struct requires a struct or a pointer to struct."},
+		{"nil pointer", func(v *Validator) *Validator { return v.Struct(nilUser) }, nil, "data error: Struct received a nil pointer."},
+		{"not a struct", func(v *Validator) *Validator { return v.Struct([]int{1}) }, nil, "data error: Struct requires a struct or a pointer to struct."},
+		{
+			"short rule slice",
+			func(v *Validator) *Validator {
+				return v.AddMapRule(map[string][]string{"name": {"string"}}, map[string]interface{}{})
+			},
+			nil,
+			"rule error: At least two name elements.",
+		},
+		{
+			"panicking rule",
+			func(v *Validator) *Validator {
+				v.TagMap["boom"] = func(args ...reflect.Value) bool { panic("boom") }
+				return v.AddRule("Name", "string", "boom", "x")
+			},
+			nil,
+			"rule error: boom",
+		},
+	}
+
+	for _, tt := range tests {
+		err := tt.build(New()).Validate()
+		if tt.wantErr != "" {
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("%s: got %v, want %q", tt.name, err, tt.wantErr)
+			}
+			continue
+		}
+
+		if got := errorKeys(err); !equalKeys(got, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestRevalidatePointer(t *testing.T) {
+	// 绑定结构体指针，修改数据后再次验证
+	user := &misuseUser{}
+	v := New().Struct(user)
+
+	if got := errorKeys(v.Validate()); !equalKeys(got, []string{"misuseUser.Name.required"}) {
+		t.Errorf("got %v", got)
+	}
+
+	user.Name = "a"
+	if err := v.Validate(); err != nil || !v.Fails || len(v.ErrorMsg) != 0 {
+		t.Errorf("got %v, Fails %v, ErrorMsg %v", err, v.Fails, v.ErrorMsg)
+	}
+}